@@ -0,0 +1,487 @@
+package srp
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSessionTTL   = 5 * time.Minute
+	defaultReapInterval = time.Minute
+	sessionIDSize       = 16
+	sessionKeySize      = 32
+)
+
+// Authenticator manages the server-side of SRP sessions, looking up ISVs in a
+// VerifierStore and keeping the state of each pending session in a
+// SessionStore between receiving the client public value and the M1 proof.
+// Pending sessions expire after a TTL and are periodically removed by a
+// background goroutine until Close is called.
+//
+// ISVs created with different parameters to the SRP, such as a smaller group
+// or a weaker hash or X function, are still used to authenticate the client
+// after which the client is asked to send a replacement ISV using the SRP
+// parameters, see Authenticator.Upgrade.
+//
+// The state of each pending session includes the server private value so it
+// is sealed with AES-GCM before it is put in the SessionStore. The key is
+// random unless it is set with SessionSecret, which is needed when several
+// Authenticators share a SessionStore.
+type Authenticator struct {
+	srp          *SRP
+	params       *Params
+	legacy       *Params
+	verifiers    VerifierStore
+	sessions     SessionStore
+	ttl          time.Duration
+	reapInterval time.Duration
+	secret       []byte
+	fakes        []*SRP
+	aead         cipher.AEAD
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// Challenge is returned by Authenticator.Start and holds the values to be
+// sent to the client.
+type Challenge struct {
+	ID   string
+	Salt []byte
+	B    []byte
+	// Params are the parameters used by the stored ISV which the client
+	// should use to create its SRP with NewSRPFromParams. If the client
+	// public value was computed with a different group or byte order then
+	// the client should start a new session with a Client created from
+	// these parameters.
+	Params *Params
+}
+
+// Result is returned by Authenticator.Finish after the client has been
+// successfully authenticated.
+type Result struct {
+	Identity []byte
+	// M2 is the server proof to be sent to the client.
+	M2 []byte
+	// Key is the key shared with the client.
+	Key []byte
+	// Upgrade is set if the stored ISV uses different parameters. The
+	// client should be sent these parameters and asked for a replacement
+	// ISV created with Client.NewUpgrade, which is passed to
+	// Authenticator.Upgrade.
+	Upgrade *Params
+
+	isv *ISV
+}
+
+var (
+	errInvalidTTL      = errors.New("duration must be positive")
+	errInvalidSessions = errors.New("session store must not be nil")
+	errInvalidSecret   = errors.New("secret must not be empty")
+	errInvalidSession  = errors.New("invalid session")
+	errNoFakeParams    = errors.New("no fake parameters")
+)
+
+// NewAuthenticator returns a new Authenticator using s and the ISVs in
+// verifiers along with any options. The Authenticator should be closed with
+// Close when it is no longer needed.
+func NewAuthenticator(s *SRP, verifiers VerifierStore, options ...func(*Authenticator) error) (*Authenticator, error) {
+	params, err := s.Params()
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Authenticator{
+		srp:          s,
+		params:       params,
+		verifiers:    verifiers,
+		sessions:     NewMemorySessionStore(),
+		ttl:          defaultSessionTTL,
+		reapInterval: defaultReapInterval,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	for _, option := range options {
+		if err := option(a); err != nil {
+			return nil, err
+		}
+	}
+
+	if a.aead == nil {
+		key, err := randBytes(s.rand, sessionKeySize)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := SessionSecret(key)(a); err != nil {
+			return nil, err
+		}
+	}
+
+	go a.reap()
+
+	return a, nil
+}
+
+// Sessions sets the SessionStore used for pending sessions. The default is a
+// MemorySessionStore.
+func Sessions(store SessionStore) func(*Authenticator) error {
+	return func(a *Authenticator) error {
+		if store == nil {
+			return errInvalidSessions
+		}
+
+		a.sessions = store
+
+		return nil
+	}
+}
+
+// SessionTTL sets how long a pending session remains valid. The default is
+// five minutes.
+func SessionTTL(d time.Duration) func(*Authenticator) error {
+	return func(a *Authenticator) error {
+		if d <= 0 {
+			return errInvalidTTL
+		}
+
+		a.ttl = d
+
+		return nil
+	}
+}
+
+// ReapInterval sets how often expired sessions are removed. The default is
+// one minute.
+func ReapInterval(d time.Duration) func(*Authenticator) error {
+	return func(a *Authenticator) error {
+		if d <= 0 {
+			return errInvalidTTL
+		}
+
+		a.reapInterval = d
+
+		return nil
+	}
+}
+
+// FakeISVs enables responding to unknown identities with a fake ISV created
+// by SRP.NewFakeISV using secret, rather than returning ErrNotFound. The
+// session then fails when Finish is called, as it would with an incorrect
+// password.
+func FakeISVs(secret []byte) func(*Authenticator) error {
+	return func(a *Authenticator) error {
+		if len(secret) == 0 {
+			return errInvalidSecret
+		}
+
+		a.secret = secret
+
+		return nil
+	}
+}
+
+// FakeParams sets the parameters used for the fake ISVs enabled by FakeISVs.
+// The default is to use the same parameters as the SRP, however while stored
+// ISVs are being upgraded from older parameters this reveals which identities
+// exist as the group and so the size of B differs. Each unknown identity is
+// given one of params, chosen with the fake ISV secret so it is the same for
+// every request, and params can be repeated to match the proportion of real
+// users still using them.
+func FakeParams(params ...*Params) func(*Authenticator) error {
+	return func(a *Authenticator) error {
+		if len(params) == 0 {
+			return errNoFakeParams
+		}
+
+		a.fakes = make([]*SRP, 0, len(params))
+
+		for _, p := range params {
+			s, err := a.srp.WithParams(p)
+			if err != nil {
+				return err
+			}
+
+			a.fakes = append(a.fakes, s)
+		}
+
+		return nil
+	}
+}
+
+// SessionSecret sets the AES key used to seal the state of pending sessions
+// in the SessionStore, which must be 16, 24 or 32 bytes. The default is a
+// random key.
+func SessionSecret(key []byte) func(*Authenticator) error {
+	return func(a *Authenticator) error {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return fmt.Errorf("unable to create cipher: %w", err)
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return fmt.Errorf("unable to create cipher: %w", err)
+		}
+
+		a.aead = aead
+
+		return nil
+	}
+}
+
+// LegacyParams sets the parameters assumed for stored ISVs that do not
+// record their own, such as those in the original unversioned format. The
+// default is to assume they use the same parameters as the SRP.
+func LegacyParams(p *Params) func(*Authenticator) error {
+	return func(a *Authenticator) error {
+		a.legacy = p
+
+		return nil
+	}
+}
+
+// Start begins a new session for the identity using the client public value
+// and returns the challenge to be sent to the client.
+func (a *Authenticator) Start(ctx context.Context, identity, xA []byte) (*Challenge, error) {
+	i, err := a.verifiers.Get(ctx, identity)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) || a.secret == nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		if i, err = a.newFakeISV(identity); err != nil {
+			return nil, err
+		}
+	}
+
+	params := a.paramsFor(i)
+
+	s, upgrade, err := a.srpFor(params)
+	if err != nil {
+		return nil, err
+	}
+
+	server, err := s.NewServer(i, xA)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := randBytes(a.srp.rand, sessionIDSize)
+	if err != nil {
+		return nil, err
+	}
+
+	b := new(bytes.Buffer)
+
+	if err := writeBytes(b, identity); err != nil {
+		return nil, err
+	}
+
+	// The ISV is kept to check it has not changed before it is upgraded
+	if upgrade {
+		_ = b.WriteByte(1)
+
+		ib, err := i.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := writeBytes(b, ib); err != nil {
+			return nil, err
+		}
+	} else {
+		_ = b.WriteByte(0)
+	}
+
+	sb, err := server.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	_, _ = b.Write(sb)
+
+	challenge := &Challenge{
+		ID:     base64.RawURLEncoding.EncodeToString(id),
+		Salt:   server.Salt(),
+		B:      server.B(),
+		Params: params,
+	}
+
+	sealed, err := a.seal(challenge.ID, b.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.sessions.Put(ctx, challenge.ID, sealed, time.Now().Add(a.ttl)); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return challenge, nil
+}
+
+// Finish completes the session using the M1 proof sent by the client. The
+// session is removed whether or not the proof is correct so it can only be
+// checked once.
+func (a *Authenticator) Finish(ctx context.Context, id string, m1 []byte) (*Result, error) {
+	sealed, err := a.sessions.Take(ctx, id)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	b, err := a.open(id, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	r := bytes.NewReader(b)
+
+	identity, err := readBytes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	upgrade, err := r.ReadByte()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var i *ISV
+
+	if upgrade != 0 {
+		ib, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+
+		i = new(ISV)
+		if err := i.UnmarshalBinary(ib); err != nil {
+			return nil, err
+		}
+	}
+
+	server := new(Server)
+	if err := server.UnmarshalBinary(b[len(b)-r.Len():]); err != nil {
+		return nil, err
+	}
+
+	m2, err := server.Check(m1)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Identity: identity,
+		M2:       m2,
+		Key:      server.Key(),
+	}
+
+	if upgrade != 0 {
+		result.Upgrade, result.isv = a.params, i
+	}
+
+	return result, nil
+}
+
+// seal encrypts the session state, using the ID as additional data so the
+// state cannot be moved to another session.
+func (a *Authenticator) seal(id string, b []byte) ([]byte, error) {
+	nonce, err := randBytes(a.srp.rand, a.aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+
+	return a.aead.Seal(nonce, nonce, b, []byte(id)), nil
+}
+
+func (a *Authenticator) open(id string, sealed []byte) ([]byte, error) {
+	n := a.aead.NonceSize()
+	if len(sealed) < n {
+		return nil, errInvalidSession
+	}
+
+	b, err := a.aead.Open(nil, sealed[:n], sealed[n:], []byte(id))
+	if err != nil {
+		return nil, errInvalidSession
+	}
+
+	return b, nil
+}
+
+func (a *Authenticator) newFakeISV(identity []byte) (*ISV, error) {
+	s := a.srp
+
+	if len(a.fakes) > 0 {
+		b := make([]byte, 8)
+		if err := a.srp.fakeBytes(a.secret, "params", identity, b); err != nil {
+			return nil, err
+		}
+
+		s = a.fakes[binary.BigEndian.Uint64(b)%uint64(len(a.fakes))]
+	}
+
+	return s.NewFakeISV(a.secret, identity)
+}
+
+// paramsFor returns the parameters used by i. If i does not record them then
+// the legacy parameters are assumed, or failing that the SRP parameters.
+func (a *Authenticator) paramsFor(i *ISV) *Params {
+	switch {
+	case i.Params != nil:
+		return i.Params
+	case a.legacy != nil:
+		return a.legacy
+	default:
+		return a.params
+	}
+}
+
+func (a *Authenticator) srpFor(params *Params) (*SRP, bool, error) {
+	if params.Equal(a.params) {
+		return a.srp, false, nil
+	}
+
+	s, err := a.srp.WithParams(params)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return s, true, nil
+}
+
+// Close stops the background removal of expired sessions.
+func (a *Authenticator) Close() error {
+	a.once.Do(func() {
+		close(a.stop)
+	})
+
+	<-a.done
+
+	return nil
+}
+
+func (a *Authenticator) reap() {
+	defer close(a.done)
+
+	ticker := time.NewTicker(a.reapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stop:
+			return
+		case now := <-ticker.C:
+			_ = a.sessions.Expire(context.Background(), now)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package srp_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthenticator(t *testing.T, s *srp.SRP, options ...func(*srp.Authenticator) error) (*srp.Authenticator, srp.VerifierStore) {
+	t.Helper()
+
+	store := srp.NewMemoryStore()
+	require.NoError(t, store.Put(context.Background(), util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))))
+
+	a, err := srp.NewAuthenticator(s, store, options...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = a.Close()
+	})
+
+	return a, store
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		option func(*srp.Authenticator) error
+		fail   bool
+	}{
+		{srp.SessionTTL(time.Second), false},
+		{srp.SessionTTL(0), true},
+		{srp.ReapInterval(time.Second), false},
+		{srp.ReapInterval(-time.Second), true},
+		{srp.Sessions(srp.NewMemorySessionStore()), false},
+		{srp.Sessions(nil), true},
+		{srp.FakeISVs([]byte("secret")), false},
+		{srp.FakeISVs(nil), true},
+		{srp.SessionSecret(make([]byte, 32)), false},
+		{srp.SessionSecret([]byte("secret")), true},
+	}
+
+	for _, table := range tables {
+		a, err := srp.NewAuthenticator(newSRP(), srp.NewMemoryStore(), table.option)
+		if table.fail {
+			assert.Error(t, err)
+
+			continue
+		}
+
+		require.NoError(t, err)
+		assert.NoError(t, a.Close())
+		assert.NoError(t, a.Close())
+	}
+}
+
+func TestAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newSRP()
+	a, _ := newAuthenticator(t, s)
+
+	_, err := a.Start(ctx, []byte("bob"), rfc5054.XA)
+	require.ErrorIs(t, err, srp.ErrNotFound)
+
+	client := util.Must(s.NewClient(rfc5054.Identity, rfc5054.Password))
+
+	challenge, err := a.Start(ctx, rfc5054.Identity, client.A())
+	require.NoError(t, err)
+	assert.NotEmpty(t, challenge.ID)
+	assert.Equal(t, util.Must(s.Params()), challenge.Params)
+
+	m1, err := client.Compute(challenge.Salt, challenge.B)
+	require.NoError(t, err)
+
+	result, err := a.Finish(ctx, challenge.ID, m1)
+	require.NoError(t, err)
+
+	assert.Equal(t, rfc5054.Identity, result.Identity)
+	assert.Equal(t, client.Key(), result.Key)
+	require.NoError(t, client.Check(result.M2))
+
+	// Sessions can only be checked once
+	_, err = a.Finish(ctx, challenge.ID, m1)
+	assert.ErrorIs(t, err, srp.ErrSessionNotFound)
+}
+
+func TestAuthenticator_Mismatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newSRP()
+	a, _ := newAuthenticator(t, s)
+
+	client := util.Must(s.NewClient(rfc5054.Identity, []byte("wrong")))
+	challenge := util.Must(a.Start(ctx, rfc5054.Identity, client.A()))
+
+	m1, err := client.Compute(challenge.Salt, challenge.B)
+	require.NoError(t, err)
+
+	_, err = a.Finish(ctx, challenge.ID, m1)
+	require.Error(t, err)
+
+	// A failed check still consumes the session
+	_, err = a.Finish(ctx, challenge.ID, m1)
+	assert.ErrorIs(t, err, srp.ErrSessionNotFound)
+}
+
+func TestAuthenticator_SessionSecret(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newSRP()
+	key := bytes.Repeat([]byte{0x01}, 32)
+	sessions := srp.NewMemorySessionStore()
+	a, store := newAuthenticator(t, s, srp.Sessions(sessions), srp.SessionSecret(key))
+
+	tables := []struct {
+		key []byte
+		err bool
+	}{
+		{key, false},
+		{bytes.Repeat([]byte{0x02}, 32), true},
+	}
+
+	for _, table := range tables {
+		// Another Authenticator sharing the SessionStore
+		b, err := srp.NewAuthenticator(s, store, srp.Sessions(sessions), srp.SessionSecret(table.key))
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = b.Close()
+		})
+
+		client := util.Must(s.NewClient(rfc5054.Identity, rfc5054.Password))
+		challenge := util.Must(a.Start(ctx, rfc5054.Identity, client.A()))
+
+		m1, err := client.Compute(challenge.Salt, challenge.B)
+		require.NoError(t, err)
+
+		_, err = b.Finish(ctx, challenge.ID, m1)
+		if table.err {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestAuthenticator_Expiry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newSRP()
+	sessions := srp.NewMemorySessionStore()
+	a, _ := newAuthenticator(t, s,
+		srp.Sessions(sessions), srp.SessionTTL(10*time.Millisecond), srp.ReapInterval(10*time.Millisecond))
+
+	client := util.Must(s.NewClient(rfc5054.Identity, rfc5054.Password))
+	challenge := util.Must(a.Start(ctx, rfc5054.Identity, client.A()))
+
+	m1, err := client.Compute(challenge.Salt, challenge.B)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return sessions.Len() == 0
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = a.Finish(ctx, challenge.ID, m1)
+	assert.ErrorIs(t, err, srp.ErrSessionNotFound)
+}
+
+func TestMemorySessionStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := srp.NewMemorySessionStore()
+
+	require.NoError(t, store.Put(ctx, "expired", []byte{0x01}, time.Now().Add(-time.Second)))
+	require.NoError(t, store.Put(ctx, "valid", []byte{0x02}, time.Now().Add(time.Minute)))
+
+	_, err := store.Take(ctx, "expired")
+	require.ErrorIs(t, err, srp.ErrSessionNotFound)
+	assert.Equal(t, 1, store.Len())
+
+	require.NoError(t, store.Put(ctx, "expired", []byte{0x01}, time.Now().Add(-time.Second)))
+	require.NoError(t, store.Expire(ctx, time.Now()))
+	assert.Equal(t, 1, store.Len())
+
+	assert.Equal(t, []byte{0x02}, util.Must(store.Take(ctx, "valid")))
+	assert.Equal(t, 0, store.Len())
+}
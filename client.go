@@ -9,16 +9,21 @@ import (
 // Client represents the client-side of an SRP session.
 type Client struct {
 	s                        *SRP
+	kdf                      KDF
 	identity, password, salt []byte
 	a, xA, xB, xS, u         *big.Int
 	m1, m2                   []byte
+	verified                 bool
 }
 
-var errClientNotReady = errors.New("set the server public key first")
+var (
+	errClientNotReady    = errors.New("set the server public key first")
+	errClientNotVerified = errors.New("check the server proof first")
+)
 
 // A returns the client public value.
 func (c *Client) A() []byte {
-	return c.xA.Bytes()
+	return c.s.Encode(c.xA)
 }
 
 // SetIdentity sets the client identity.
@@ -26,15 +31,22 @@ func (c *Client) SetIdentity(identity []byte) {
 	c.identity = identity
 }
 
+// SetKDF overrides the function for computing the X value for this client
+// only, typically with the KDF returned by NewKDF using the parameters sent
+// by the server along with the salt.
+func (c *Client) SetKDF(k KDF) {
+	c.kdf = k
+}
+
 // Compute takes the salt and public value provided by the server and computes
 // the proofs and shared key. It returns the M1 proof to be sent to the server.
 func (c *Client) Compute(salt, xB []byte) ([]byte, error) {
-	b := new(big.Int).SetBytes(xB)
+	b := c.s.Decode(xB)
 	if new(big.Int).Mod(b, c.s.Group().N).Sign() == 0 {
 		return nil, ErrInvalidPublicKey
 	}
 
-	c.xB, c.salt = b, salt
+	c.xB, c.salt, c.verified = b, salt, false
 
 	var err error
 
@@ -43,9 +55,15 @@ func (c *Client) Compute(salt, xB []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	c.xS = c.s.computeClientS(c.a, c.xB, c.s.multiplier(), c.u, c.s.computeX(c.identity, c.password, c.salt))
-	c.m1 = c.s.computeM1(c.xA, c.xB, c.s.computeK(c.xS), c.identity, c.salt)
-	c.m2 = c.s.computeM2(c.xA, c.m1, c.s.computeK(c.xS))
+	x, err := c.s.computeX(c.kdf, c.identity, c.password, c.salt)
+	if err != nil {
+		return nil, err
+	}
+
+	c.xS = c.s.computeClientS(c.a, c.xB, c.s.multiplier(), c.u, x)
+	xK := c.s.computeK(c.xS)
+	c.m1 = c.s.computeM1(c.xA, c.xB, c.xS, xK, c.identity, c.salt)
+	c.m2 = c.s.computeM2(c.xA, c.xS, c.m1, xK)
 
 	return c.m1, nil
 }
@@ -57,7 +75,7 @@ func (c *Client) S() ([]byte, error) {
 		return nil, errClientNotReady
 	}
 
-	return c.xS.Bytes(), nil
+	return c.s.Encode(c.xS), nil
 }
 
 // U returns the computed U value after c.Compute() has been called, otherwise
@@ -67,15 +85,21 @@ func (c *Client) U() ([]byte, error) {
 		return nil, errClientNotReady
 	}
 
-	return c.u.Bytes(), nil
+	return c.s.Encode(c.u), nil
 }
 
 // Check compares the M2 proof computed by the server with the clients copy.
 func (c *Client) Check(m2 []byte) error {
+	if c.m2 == nil {
+		return errClientNotReady
+	}
+
 	if subtle.ConstantTimeCompare(m2, c.m2) != 1 {
 		return errMismatchedProof
 	}
 
+	c.verified = true
+
 	return nil
 }
 
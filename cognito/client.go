@@ -0,0 +1,411 @@
+package cognito
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bodgit/srp"
+)
+
+// Values of the AuthFlow and ChallengeName fields.
+const (
+	AuthFlowUserSRPAuth       = "USER_SRP_AUTH"
+	ChallengePasswordVerifier = "PASSWORD_VERIFIER"
+)
+
+// Values of the X-Amz-Target header for each supported operation.
+const (
+	TargetInitiateAuth           = "AWSCognitoIdentityProviderService.InitiateAuth"
+	TargetRespondToAuthChallenge = "AWSCognitoIdentityProviderService.RespondToAuthChallenge"
+	TargetConfirmDevice          = "AWSCognitoIdentityProviderService.ConfirmDevice"
+)
+
+// ContentType is the content type of requests and responses using the AWS
+// JSON 1.1 protocol.
+const ContentType = "application/x-amz-json-1.1"
+
+var (
+	// ErrUnexpectedChallenge means Cognito returned a challenge that is
+	// not supported.
+	ErrUnexpectedChallenge = errors.New("unexpected challenge")
+
+	// ErrNoAuthenticationResult means Cognito did not return any tokens.
+	ErrNoAuthenticationResult = errors.New("no authentication result")
+
+	// ErrNoDeviceMetadata means Cognito did not return a new device.
+	ErrNoDeviceMetadata = errors.New("no device metadata")
+
+	errInvalidEndpoint   = errors.New("endpoint must not be empty")
+	errInvalidHTTPClient = errors.New("HTTP client must not be nil")
+)
+
+// InitiateAuthInput is the request body of InitiateAuth.
+type InitiateAuthInput struct {
+	AuthFlow       string            `json:"AuthFlow"`
+	AuthParameters map[string]string `json:"AuthParameters"`
+	ClientID       string            `json:"ClientId"`
+}
+
+// InitiateAuthOutput is the response body of InitiateAuth.
+type InitiateAuthOutput struct {
+	AuthenticationResult *AuthenticationResult `json:"AuthenticationResult,omitempty"`
+	ChallengeName        string                `json:"ChallengeName,omitempty"`
+	ChallengeParameters  map[string]string     `json:"ChallengeParameters,omitempty"`
+	Session              string                `json:"Session,omitempty"`
+}
+
+// RespondToAuthChallengeInput is the request body of RespondToAuthChallenge.
+type RespondToAuthChallengeInput struct {
+	ChallengeName      string            `json:"ChallengeName"`
+	ChallengeResponses map[string]string `json:"ChallengeResponses"`
+	ClientID           string            `json:"ClientId"`
+	Session            string            `json:"Session,omitempty"`
+}
+
+// RespondToAuthChallengeOutput is the response body of
+// RespondToAuthChallenge.
+type RespondToAuthChallengeOutput InitiateAuthOutput
+
+// ConfirmDeviceInput is the request body of ConfirmDevice.
+type ConfirmDeviceInput struct {
+	AccessToken                string                      `json:"AccessToken"`
+	DeviceKey                  string                      `json:"DeviceKey"`
+	DeviceName                 string                      `json:"DeviceName,omitempty"`
+	DeviceSecretVerifierConfig *DeviceSecretVerifierConfig `json:"DeviceSecretVerifierConfig"`
+}
+
+// ConfirmDeviceOutput is the response body of ConfirmDevice.
+type ConfirmDeviceOutput struct {
+	UserConfirmationNecessary bool `json:"UserConfirmationNecessary"`
+}
+
+// AuthenticationResult holds the tokens returned after a successful login.
+type AuthenticationResult struct {
+	AccessToken       string             `json:"AccessToken"`
+	ExpiresIn         int                `json:"ExpiresIn"`
+	IDToken           string             `json:"IdToken"`
+	NewDeviceMetadata *NewDeviceMetadata `json:"NewDeviceMetadata,omitempty"`
+	RefreshToken      string             `json:"RefreshToken,omitempty"`
+	TokenType         string             `json:"TokenType"`
+}
+
+// NewDeviceMetadata holds the keys of a new device, which can be confirmed
+// with ConfirmDevice.
+type NewDeviceMetadata struct {
+	DeviceGroupKey string `json:"DeviceGroupKey"`
+	DeviceKey      string `json:"DeviceKey"`
+}
+
+// Error is returned when Cognito responds with an error.
+type Error struct {
+	StatusCode int
+	Type       string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s (status %d)", e.Type, e.StatusCode)
+	}
+
+	return fmt.Sprintf("%s: %s (status %d)", e.Type, e.Message, e.StatusCode)
+}
+
+// Client logs in to a Cognito user pool using USER_SRP_AUTH.
+type Client struct {
+	poolID       string
+	clientID     string
+	clientSecret string
+	endpoint     string
+	httpClient   *http.Client
+	srpOptions   []func(*srp.SRP) error
+	device       *Device
+}
+
+// NewClient returns a new Client for the app client clientID in the user pool
+// identified by poolID along with any options. The endpoint defaults to the
+// Cognito endpoint in the region of the user pool.
+func NewClient(poolID, clientID string, options ...func(*Client) error) (*Client, error) {
+	region, _, err := SplitPoolID(poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		poolID:     poolID,
+		clientID:   clientID,
+		endpoint:   "https://cognito-idp." + region + ".amazonaws.com/",
+		httpClient: http.DefaultClient,
+	}
+
+	for _, option := range options {
+		if err := option(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Endpoint sets the URL requests are sent to.
+func Endpoint(url string) func(*Client) error {
+	return func(c *Client) error {
+		if url == "" {
+			return errInvalidEndpoint
+		}
+
+		c.endpoint = url
+
+		return nil
+	}
+}
+
+// ClientSecret sets the secret of the app client, which is used to compute
+// the SECRET_HASH parameter.
+func ClientSecret(secret string) func(*Client) error {
+	return func(c *Client) error {
+		c.clientSecret = secret
+
+		return nil
+	}
+}
+
+// HTTPClient sets the http.Client used to send requests. The default is
+// http.DefaultClient.
+func HTTPClient(client *http.Client) func(*Client) error {
+	return func(c *Client) error {
+		if client == nil {
+			return errInvalidHTTPClient
+		}
+
+		c.httpClient = client
+
+		return nil
+	}
+}
+
+// SRPOptions sets additional options passed to NewSRP.
+func SRPOptions(options ...func(*srp.SRP) error) func(*Client) error {
+	return func(c *Client) error {
+		c.srpOptions = options
+
+		return nil
+	}
+}
+
+// RememberedDevice sets the remembered device used to skip any MFA
+// challenge. The device is authenticated with DEVICE_SRP_AUTH after the user.
+func RememberedDevice(d *Device) func(*Client) error {
+	return func(c *Client) error {
+		c.device = d
+
+		return nil
+	}
+}
+
+// Login authenticates username with password and returns the tokens.
+func (c *Client) Login(ctx context.Context, username, password string) (*AuthenticationResult, error) {
+	u, err := NewUser(c.poolID, username, password, c.srpOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	params := u.AuthParameters()
+	c.setDeviceKey(params)
+	c.setSecretHash(params)
+
+	out := new(InitiateAuthOutput)
+	if err := c.call(ctx, TargetInitiateAuth, &InitiateAuthInput{
+		AuthFlow:       AuthFlowUserSRPAuth,
+		AuthParameters: params,
+		ClientID:       c.clientID,
+	}, out); err != nil {
+		return nil, err
+	}
+
+	if out.ChallengeName != ChallengePasswordVerifier {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedChallenge, out.ChallengeName)
+	}
+
+	responses, err := u.PasswordVerifier(out.ChallengeParameters, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	c.setDeviceKey(responses)
+
+	resp, err := c.respond(ctx, ChallengePasswordVerifier, out.Session, responses)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.ChallengeName == ChallengeDeviceSRPAuth && c.device != nil {
+		if resp, err = c.deviceAuth(ctx, responses[ParamUsername], resp.Session); err != nil {
+			return nil, err
+		}
+	}
+
+	return authenticationResult(resp)
+}
+
+// ConfirmDevice generates a password for the new device returned in the
+// AuthenticationResult and confirms it using the access token. The returned
+// Device should be stored and passed with the RememberedDevice option for
+// subsequent logins. Any device name is optional.
+func (c *Client) ConfirmDevice(ctx context.Context, result *AuthenticationResult, name string) (*Device, bool, error) {
+	if result.NewDeviceMetadata == nil {
+		return nil, false, ErrNoDeviceMetadata
+	}
+
+	d, i, err := NewDevice(result.NewDeviceMetadata.DeviceGroupKey, result.NewDeviceMetadata.DeviceKey, nil, c.srpOptions...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	out := new(ConfirmDeviceOutput)
+	if err := c.call(ctx, TargetConfirmDevice, &ConfirmDeviceInput{
+		AccessToken:                result.AccessToken,
+		DeviceKey:                  d.Key,
+		DeviceName:                 name,
+		DeviceSecretVerifierConfig: NewDeviceSecretVerifierConfig(i),
+	}, out); err != nil {
+		return nil, false, err
+	}
+
+	return d, out.UserConfirmationNecessary, nil
+}
+
+// SecretHash returns the SECRET_HASH parameter for username using the secret
+// of the app client clientID.
+func SecretHash(secret, username, clientID string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	_, _ = h.Write([]byte(username + clientID))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func (c *Client) setSecretHash(params map[string]string) {
+	if c.clientSecret != "" {
+		params[ParamSecretHash] = SecretHash(c.clientSecret, params[ParamUsername], c.clientID)
+	}
+}
+
+func (c *Client) setDeviceKey(params map[string]string) {
+	if c.device != nil {
+		params[ParamDeviceKey] = c.device.Key
+	}
+}
+
+func (c *Client) deviceAuth(ctx context.Context, username, session string) (*RespondToAuthChallengeOutput, error) {
+	a, err := NewDeviceAuth(c.device, username, c.srpOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.respond(ctx, ChallengeDeviceSRPAuth, session, a.SRPAuth())
+	if err != nil {
+		return nil, err
+	}
+
+	if out.ChallengeName != ChallengeDevicePasswordVerifier {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedChallenge, out.ChallengeName)
+	}
+
+	responses, err := a.PasswordVerifier(out.ChallengeParameters, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.respond(ctx, ChallengeDevicePasswordVerifier, out.Session, responses)
+}
+
+func (c *Client) respond(ctx context.Context, name, session string, responses map[string]string) (*RespondToAuthChallengeOutput, error) {
+	c.setSecretHash(responses)
+
+	out := new(RespondToAuthChallengeOutput)
+	if err := c.call(ctx, TargetRespondToAuthChallenge, &RespondToAuthChallengeInput{
+		ChallengeName:      name,
+		ChallengeResponses: responses,
+		ClientID:           c.clientID,
+		Session:            session,
+	}, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func authenticationResult(out *RespondToAuthChallengeOutput) (*AuthenticationResult, error) {
+	if out.ChallengeName != "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedChallenge, out.ChallengeName)
+	}
+
+	if out.AuthenticationResult == nil {
+		return nil, ErrNoAuthenticationResult
+	}
+
+	return out.AuthenticationResult, nil
+}
+
+func (c *Client) call(ctx context.Context, target string, in, out interface{}) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("unable to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", ContentType)
+	req.Header.Set("X-Amz-Target", target)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decodeError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("unable to decode response: %w", err)
+	}
+
+	return nil
+}
+
+func decodeError(resp *http.Response) error {
+	var body struct {
+		Type    string `json:"__type"`
+		Message string `json:"message"`
+	}
+
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+
+	e := &Error{
+		StatusCode: resp.StatusCode,
+		Type:       body.Type,
+		Message:    body.Message,
+	}
+
+	// The type may be prefixed with a namespace, e.g.
+	// "com.amazonaws.cognito#NotAuthorizedException"
+	if i := strings.LastIndexByte(e.Type, '#'); i >= 0 {
+		e.Type = e.Type[i+1:]
+	}
+
+	return e
+}
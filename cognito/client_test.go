@@ -0,0 +1,302 @@
+package cognito_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/cognito"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vectors "github.com/bodgit/srp/internal/cognito"
+)
+
+const (
+	clientID     = "1example23456789"
+	clientSecret = "secret"
+)
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", cognito.ContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func notAuthorized(w http.ResponseWriter) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{
+		"__type":  "NotAuthorizedException",
+		"message": "Incorrect username or password.",
+	})
+}
+
+func checkSignature(responses map[string]string, key, prefix []byte) bool {
+	secretBlock := util.Must(base64.StdEncoding.DecodeString(vectors.SecretBlock))
+
+	return responses[cognito.ParamPasswordClaimSignature] == cognito.ClaimSignature(key, prefix, secretBlock, responses[cognito.ParamTimestamp])
+}
+
+var tokens = &cognito.AuthenticationResult{ //nolint:gochecknoglobals
+	AccessToken: "access",
+	ExpiresIn:   3600,
+	IDToken:     "id",
+	TokenType:   "Bearer",
+}
+
+//nolint:cyclop,funlen
+func newPool(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	_, name, _ := cognito.SplitPoolID(vectors.PoolID)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, cognito.ContentType, r.Header.Get("Content-Type"))
+
+		switch r.Header.Get("X-Amz-Target") {
+		case cognito.TargetInitiateAuth:
+			in := new(cognito.InitiateAuthInput)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(in))
+
+			assert.Equal(t, cognito.AuthFlowUserSRPAuth, in.AuthFlow)
+			assert.Equal(t, clientID, in.ClientID)
+			assert.Equal(t, vectors.Username, in.AuthParameters[cognito.ParamUsername])
+			assert.Equal(t, hex.EncodeToString(vectors.A), in.AuthParameters[cognito.ParamSRPA])
+			assert.Equal(t, cognito.SecretHash(clientSecret, vectors.Username, clientID), in.AuthParameters[cognito.ParamSecretHash])
+
+			writeJSON(w, http.StatusOK, &cognito.InitiateAuthOutput{
+				ChallengeName: cognito.ChallengePasswordVerifier,
+				ChallengeParameters: map[string]string{
+					cognito.ParamSalt:         vectors.Salt,
+					cognito.ParamSRPB:         hex.EncodeToString(vectors.B),
+					cognito.ParamSecretBlock:  vectors.SecretBlock,
+					cognito.ParamUserIDForSRP: vectors.UserID,
+					cognito.ParamUsername:     vectors.UserID,
+				},
+				Session: "session",
+			})
+		case cognito.TargetRespondToAuthChallenge:
+			in := new(cognito.RespondToAuthChallengeInput)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(in))
+
+			assert.Equal(t, "session", in.Session)
+			assert.Equal(t, cognito.SecretHash(clientSecret, vectors.UserID, clientID), in.ChallengeResponses[cognito.ParamSecretHash])
+
+			switch in.ChallengeName {
+			case cognito.ChallengePasswordVerifier:
+				if !checkSignature(in.ChallengeResponses, vectors.Key, []byte(name+vectors.UserID)) {
+					notAuthorized(w)
+
+					return
+				}
+
+				if _, ok := in.ChallengeResponses[cognito.ParamDeviceKey]; ok {
+					writeJSON(w, http.StatusOK, &cognito.RespondToAuthChallengeOutput{
+						ChallengeName: cognito.ChallengeDeviceSRPAuth,
+						Session:       "session",
+					})
+
+					return
+				}
+
+				result := *tokens
+				result.NewDeviceMetadata = &cognito.NewDeviceMetadata{
+					DeviceGroupKey: vectors.DeviceGroupKey,
+					DeviceKey:      vectors.DeviceKey,
+				}
+
+				writeJSON(w, http.StatusOK, &cognito.RespondToAuthChallengeOutput{
+					AuthenticationResult: &result,
+				})
+			case cognito.ChallengeDeviceSRPAuth:
+				assert.Equal(t, map[string]string{
+					cognito.ParamUsername:   vectors.UserID,
+					cognito.ParamDeviceKey:  vectors.DeviceKey,
+					cognito.ParamSRPA:       hex.EncodeToString(vectors.A),
+					cognito.ParamSecretHash: cognito.SecretHash(clientSecret, vectors.UserID, clientID),
+				}, in.ChallengeResponses)
+
+				writeJSON(w, http.StatusOK, &cognito.RespondToAuthChallengeOutput{
+					ChallengeName: cognito.ChallengeDevicePasswordVerifier,
+					ChallengeParameters: map[string]string{
+						cognito.ParamSalt:        hex.EncodeToString(vectors.DeviceSalt),
+						cognito.ParamSRPB:        hex.EncodeToString(vectors.DeviceB),
+						cognito.ParamSecretBlock: vectors.SecretBlock,
+						cognito.ParamUsername:    vectors.UserID,
+						cognito.ParamDeviceKey:   vectors.DeviceKey,
+					},
+					Session: "session",
+				})
+			case cognito.ChallengeDevicePasswordVerifier:
+				if !checkSignature(in.ChallengeResponses, vectors.DeviceDerivedKey, []byte(vectors.DeviceGroupKey+vectors.DeviceKey)) {
+					notAuthorized(w)
+
+					return
+				}
+
+				writeJSON(w, http.StatusOK, &cognito.RespondToAuthChallengeOutput{
+					AuthenticationResult: tokens,
+				})
+			}
+		case cognito.TargetConfirmDevice:
+			in := new(cognito.ConfirmDeviceInput)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(in))
+
+			assert.Equal(t, tokens.AccessToken, in.AccessToken)
+			assert.Equal(t, vectors.DeviceKey, in.DeviceKey)
+			assert.NotNil(t, in.DeviceSecretVerifierConfig)
+
+			writeJSON(w, http.StatusOK, &cognito.ConfirmDeviceOutput{})
+		default:
+			writeJSON(w, http.StatusBadRequest, map[string]string{
+				"__type": "com.amazonaws.cognito#UnknownOperationException",
+			})
+		}
+	}))
+}
+
+func newClient(t *testing.T, ts *httptest.Server, options ...func(*cognito.Client) error) *cognito.Client {
+	t.Helper()
+
+	// Enough for both the user and device private values
+	a := util.Pad(new(big.Int).SetBytes(rfc5054.A), 384)
+	r := bytes.NewReader(append(append([]byte{}, a...), a...))
+
+	c, err := cognito.NewClient(vectors.PoolID, clientID, append([]func(*cognito.Client) error{
+		cognito.Endpoint(ts.URL),
+		cognito.ClientSecret(clientSecret),
+		cognito.HTTPClient(ts.Client()),
+		cognito.SRPOptions(srp.Rand(r)),
+	}, options...)...)
+	require.NoError(t, err)
+
+	return c
+}
+
+//nolint:funlen
+func TestClientLogin(t *testing.T) {
+	t.Parallel()
+
+	errNotAuthorized := &cognito.Error{
+		StatusCode: http.StatusBadRequest,
+		Type:       "NotAuthorizedException",
+		Message:    "Incorrect username or password.",
+	}
+
+	tables := []struct {
+		name     string
+		password string
+		device   *cognito.Device
+		result   *cognito.AuthenticationResult
+		err      error
+	}{
+		{
+			"success",
+			vectors.Password,
+			nil,
+			&cognito.AuthenticationResult{
+				AccessToken: "access",
+				ExpiresIn:   3600,
+				IDToken:     "id",
+				NewDeviceMetadata: &cognito.NewDeviceMetadata{
+					DeviceGroupKey: vectors.DeviceGroupKey,
+					DeviceKey:      vectors.DeviceKey,
+				},
+				TokenType: "Bearer",
+			},
+			nil,
+		},
+		{
+			"incorrect password",
+			"incorrect",
+			nil,
+			nil,
+			errNotAuthorized,
+		},
+		{
+			"remembered device",
+			vectors.Password,
+			&cognito.Device{
+				GroupKey: vectors.DeviceGroupKey,
+				Key:      vectors.DeviceKey,
+				Password: vectors.DevicePassword,
+			},
+			tokens,
+			nil,
+		},
+		{
+			"incorrect device password",
+			vectors.Password,
+			&cognito.Device{
+				GroupKey: vectors.DeviceGroupKey,
+				Key:      vectors.DeviceKey,
+				Password: "incorrect",
+			},
+			nil,
+			errNotAuthorized,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			ts := newPool(t)
+			defer ts.Close()
+
+			var options []func(*cognito.Client) error
+			if table.device != nil {
+				options = append(options, cognito.RememberedDevice(table.device))
+			}
+
+			result, err := newClient(t, ts, options...).Login(context.Background(), vectors.Username, table.password)
+			if table.err != nil {
+				assert.Equal(t, table.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, table.result, result)
+		})
+	}
+}
+
+func TestClientConfirmDevice(t *testing.T) {
+	t.Parallel()
+
+	ts := newPool(t)
+	defer ts.Close()
+
+	c := newClient(t, ts)
+
+	result, err := c.Login(context.Background(), vectors.Username, vectors.Password)
+	require.NoError(t, err)
+
+	d, confirm, err := c.ConfirmDevice(context.Background(), result, "test")
+	require.NoError(t, err)
+
+	assert.False(t, confirm)
+	assert.Equal(t, vectors.DeviceGroupKey, d.GroupKey)
+	assert.Equal(t, vectors.DeviceKey, d.Key)
+
+	_, _, err = c.ConfirmDevice(context.Background(), tokens, "test")
+	assert.ErrorIs(t, err, cognito.ErrNoDeviceMetadata)
+}
+
+func TestSecretHash(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "SRSokfDPYgNH3Tm3eRbqKCsFZg9Ia07+tqRIgXhLH5s=", cognito.SecretHash(clientSecret, vectors.Username, clientID))
+}
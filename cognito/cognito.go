@@ -1,5 +1,10 @@
 // Package cognito contains the SRP primitives that differ between RFC 5054
 // and the AWS Cognito implementation.
+//
+// It also includes a client for logging in to a Cognito user pool with
+// USER_SRP_AUTH, including remembered devices with DEVICE_SRP_AUTH. The
+// cognitotest package provides a fake user pool that can be used to test it
+// without access to AWS.
 package cognito
 
 import (
@@ -7,13 +12,12 @@ import (
 	"math/big"
 
 	"github.com/bodgit/srp"
-	"github.com/bodgit/srp/internal/rfc5054"
 	"github.com/bodgit/srp/internal/util"
 )
 
 //nolint:gochecknoglobals
 var cognitoGroups = map[int]*srp.Group{
-	3072: util.Must(srp.NewGroup(2, 3072, rfc5054.Hex3072)),
+	3072: util.Must(srp.GetGroupByName("modp3072")),
 }
 
 // GetGroup returns the AWS Cognito group for the prime of n bits.
@@ -27,15 +31,22 @@ func GetGroup(n int) (*srp.Group, error) {
 }
 
 // NewSRP returns a new srp.SRP struct with the Cognito-specific options
-// already set.
-func NewSRP() (*srp.SRP, error) {
+// already set along with any additional options.
+func NewSRP(options ...func(*srp.SRP) error) (*srp.SRP, error) {
 	//nolint:wrapcheck
-	return srp.NewSRP(crypto.SHA256, util.Must(GetGroup(3072)), srp.K(Multiplier), srp.U(ComputeU), srp.X(ComputeX))
+	return srp.NewSRP(crypto.SHA256, util.Must(GetGroup(3072)), append([]func(*srp.SRP) error{
+		srp.K(Multiplier), srp.U(ComputeU), srp.X(ComputeX),
+	}, options...)...)
 }
 
 // Pad prepends a zero byte to slice b if the first byte is greater than or
-// equal to 0x80.
+// equal to 0x80. An empty slice, such as the bytes of a zero big.Int, is
+// returned as a single zero byte.
 func Pad(b []byte) []byte {
+	if len(b) == 0 {
+		return []byte{0x00}
+	}
+
 	if b[0] >= 0x80 {
 		b = append([]byte{0x00}, b...)
 	}
@@ -36,6 +36,22 @@ func TestGetGroup(t *testing.T) {
 	}
 }
 
+func TestPad(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		b, want []byte
+	}{
+		{nil, []byte{0x00}},
+		{[]byte{0x7f}, []byte{0x7f}},
+		{[]byte{0x80}, []byte{0x00, 0x80}},
+	}
+
+	for _, table := range tables {
+		assert.Equal(t, table.want, cognito.Pad(table.b))
+	}
+}
+
 func TestMultiplier(t *testing.T) {
 	t.Parallel()
 
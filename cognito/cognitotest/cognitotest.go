@@ -0,0 +1,493 @@
+// Package cognitotest provides a fake AWS Cognito user pool for testing
+// clients of the USER_SRP_AUTH flow without access to AWS.
+package cognitotest
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/cognito"
+)
+
+const (
+	secretBlockSize = 64
+	sessionSize     = 32
+	tokenSize       = 32
+	expiresIn       = 3600
+
+	defaultSessionTTL    = 3 * time.Minute
+	defaultTimestampSkew = 5 * time.Minute
+)
+
+// Types of the errors returned by the Pool, matching those returned by
+// Cognito.
+const (
+	InvalidParameterException = "InvalidParameterException"
+	NotAuthorizedException    = "NotAuthorizedException"
+	ResourceNotFoundException = "ResourceNotFoundException"
+	SerializationException    = "SerializationException"
+	UnknownOperationException = "UnknownOperationException"
+	UserNotFoundException     = "UserNotFoundException"
+)
+
+const incorrectCredentials = "Incorrect username or password."
+
+var (
+	// ErrUserExists means a user with the same username has already been
+	// added.
+	ErrUserExists = errors.New("user already exists")
+
+	errInvalidDuration = errors.New("duration must be positive")
+	errInvalidClock    = errors.New("clock must not be nil")
+)
+
+type user struct {
+	username string
+	id       string
+	isv      *srp.ISV
+}
+
+type session struct {
+	user        *user
+	server      *srp.Server
+	xA          []byte
+	secretBlock []byte
+	expires     time.Time
+}
+
+// Pool is a fake user pool implementing the server-side of USER_SRP_AUTH. It
+// implements http.Handler and answers InitiateAuth and
+// RespondToAuthChallenge requests using the AWS JSON 1.1 protocol. Successful
+// logins are issued random dummy tokens.
+//
+// As with Cognito, the session returned by InitiateAuth expires if the
+// challenge is not answered in time and the TIMESTAMP sent with the challenge
+// response must be close to the current time.
+type Pool struct {
+	poolName     string
+	clientID     string
+	clientSecret string
+	srp          *srp.SRP
+	ttl          time.Duration
+	skew         time.Duration
+	now          func() time.Time
+
+	mu       sync.Mutex
+	users    map[string]*user
+	sessions map[string]*session
+	tokens   map[string]string
+}
+
+// NewPool returns a new Pool identified by poolID with a single app client
+// clientID along with any options.
+func NewPool(poolID, clientID string, options ...func(*Pool) error) (*Pool, error) {
+	_, poolName, err := cognito.SplitPoolID(poolID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	s, err := cognito.NewSRP()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	p := &Pool{
+		poolName: poolName,
+		clientID: clientID,
+		srp:      s,
+		ttl:      defaultSessionTTL,
+		skew:     defaultTimestampSkew,
+		now:      time.Now,
+		users:    make(map[string]*user),
+		sessions: make(map[string]*session),
+		tokens:   make(map[string]string),
+	}
+
+	for _, option := range options {
+		if err := option(p); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// ClientSecret sets the secret of the app client, which means requests must
+// include the correct SECRET_HASH parameter.
+func ClientSecret(secret string) func(*Pool) error {
+	return func(p *Pool) error {
+		p.clientSecret = secret
+
+		return nil
+	}
+}
+
+// SessionTTL sets how long the session returned by InitiateAuth remains
+// valid. The default is three minutes.
+func SessionTTL(d time.Duration) func(*Pool) error {
+	return func(p *Pool) error {
+		if d <= 0 {
+			return errInvalidDuration
+		}
+
+		p.ttl = d
+
+		return nil
+	}
+}
+
+// TimestampSkew sets how far the TIMESTAMP sent with the challenge response
+// can be from the current time. The default is five minutes.
+func TimestampSkew(d time.Duration) func(*Pool) error {
+	return func(p *Pool) error {
+		if d <= 0 {
+			return errInvalidDuration
+		}
+
+		p.skew = d
+
+		return nil
+	}
+}
+
+// Clock sets the function used to get the current time. The default is
+// time.Now.
+func Clock(now func() time.Time) func(*Pool) error {
+	return func(p *Pool) error {
+		if now == nil {
+			return errInvalidClock
+		}
+
+		p.now = now
+
+		return nil
+	}
+}
+
+// NewServer starts and returns a new httptest.Server using p. The caller
+// should call Close when finished.
+func (p *Pool) NewServer() *httptest.Server {
+	return httptest.NewServer(p)
+}
+
+// AddUser registers a new user with the username and password. The user is
+// assigned a random ID which is returned and used as the USER_ID_FOR_SRP
+// parameter.
+func (p *Pool) AddUser(username, password string) (string, error) {
+	b, err := randBytes(16) //nolint:gomnd
+	if err != nil {
+		return "", err
+	}
+
+	// Format as a version 4 UUID
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	id := fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+
+	i, err := cognito.NewISV(p.srp, []byte(p.poolName+id), []byte(password), nil)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.users[username]; ok {
+		return "", ErrUserExists
+	}
+
+	p.users[username] = &user{
+		username: username,
+		id:       id,
+		isv:      i,
+	}
+
+	return id, nil
+}
+
+// Username returns the username the access token was issued to.
+func (p *Pool) Username(accessToken string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	username, ok := p.tokens[accessToken]
+
+	return username, ok
+}
+
+// ServeHTTP satisfies the http.Handler interface.
+func (p *Pool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, UnknownOperationException, "")
+
+		return
+	}
+
+	var (
+		resp interface{}
+		err  error
+	)
+
+	switch r.Header.Get("X-Amz-Target") {
+	case cognito.TargetInitiateAuth:
+		in := new(cognito.InitiateAuthInput)
+		if err = json.NewDecoder(r.Body).Decode(in); err == nil {
+			resp, err = p.initiateAuth(in)
+		}
+	case cognito.TargetRespondToAuthChallenge:
+		in := new(cognito.RespondToAuthChallengeInput)
+		if err = json.NewDecoder(r.Body).Decode(in); err == nil {
+			resp, err = p.respondToAuthChallenge(in)
+		}
+	default:
+		writeError(w, UnknownOperationException, "")
+
+		return
+	}
+
+	var e *apiError
+
+	switch {
+	case errors.As(err, &e):
+		writeError(w, e.Type, e.Message)
+	case err != nil:
+		writeError(w, SerializationException, err.Error())
+	default:
+		w.Header().Set("Content-Type", cognito.ContentType)
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+//nolint:cyclop
+func (p *Pool) initiateAuth(in *cognito.InitiateAuthInput) (*cognito.InitiateAuthOutput, error) {
+	if in.ClientID != p.clientID {
+		return nil, &apiError{ResourceNotFoundException, "User pool client " + in.ClientID + " does not exist."}
+	}
+
+	if in.AuthFlow != cognito.AuthFlowUserSRPAuth {
+		return nil, &apiError{InvalidParameterException, "Unsupported auth flow."}
+	}
+
+	username := in.AuthParameters[cognito.ParamUsername]
+
+	if err := p.checkSecretHash(in.AuthParameters, username); err != nil {
+		return nil, err
+	}
+
+	xA, err := hex.DecodeString(in.AuthParameters[cognito.ParamSRPA])
+	if err != nil || len(xA) == 0 {
+		return nil, &apiError{InvalidParameterException, "Missing required parameter SRP_A"}
+	}
+
+	p.mu.Lock()
+	u, ok := p.users[username]
+	p.mu.Unlock()
+
+	if !ok {
+		return nil, &apiError{UserNotFoundException, "User does not exist."}
+	}
+
+	server, err := p.srp.NewServer(u.isv, xA)
+	if err != nil {
+		return nil, &apiError{InvalidParameterException, "Invalid SRP_A"}
+	}
+
+	secretBlock, err := randBytes(secretBlockSize)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := p.newSession(&session{
+		user:        u,
+		server:      server,
+		xA:          xA,
+		secretBlock: secretBlock,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cognito.InitiateAuthOutput{
+		ChallengeName: cognito.ChallengePasswordVerifier,
+		ChallengeParameters: map[string]string{
+			cognito.ParamSalt:         hex.EncodeToString(server.Salt()),
+			cognito.ParamSRPB:         hex.EncodeToString(server.B()),
+			cognito.ParamSecretBlock:  base64.StdEncoding.EncodeToString(secretBlock),
+			cognito.ParamUserIDForSRP: u.id,
+			cognito.ParamUsername:     u.id,
+		},
+		Session: id,
+	}, nil
+}
+
+//nolint:cyclop
+func (p *Pool) respondToAuthChallenge(in *cognito.RespondToAuthChallengeInput) (*cognito.RespondToAuthChallengeOutput, error) {
+	if in.ClientID != p.clientID {
+		return nil, &apiError{ResourceNotFoundException, "User pool client " + in.ClientID + " does not exist."}
+	}
+
+	if in.ChallengeName != cognito.ChallengePasswordVerifier {
+		return nil, &apiError{InvalidParameterException, "Unsupported challenge."}
+	}
+
+	// Sessions can only be used once
+	p.mu.Lock()
+	s, ok := p.sessions[in.Session]
+	delete(p.sessions, in.Session)
+	p.mu.Unlock()
+
+	if !ok {
+		return nil, &apiError{NotAuthorizedException, "Invalid session for the user."}
+	}
+
+	now := p.now()
+
+	if now.After(s.expires) {
+		return nil, &apiError{NotAuthorizedException, "Invalid session for the user, session is expired."}
+	}
+
+	responses := in.ChallengeResponses
+
+	if err := p.checkSecretHash(responses, responses[cognito.ParamUsername]); err != nil {
+		return nil, err
+	}
+
+	if responses[cognito.ParamUsername] != s.user.id ||
+		responses[cognito.ParamPasswordClaimSecretBlock] != base64.StdEncoding.EncodeToString(s.secretBlock) {
+		return nil, &apiError{NotAuthorizedException, incorrectCredentials}
+	}
+
+	ts := responses[cognito.ParamTimestamp]
+
+	t, err := time.Parse("Mon Jan 2 15:04:05 MST 2006", ts)
+	if err != nil || t.Before(now.Add(-p.skew)) || t.After(now.Add(p.skew)) {
+		return nil, &apiError{InvalidParameterException, "Invalid TIMESTAMP"}
+	}
+
+	key, err := cognito.DeriveKey(s.server.S(), p.computeU(s))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	signature := cognito.ClaimSignature(key, []byte(p.poolName+s.user.id), s.secretBlock, ts)
+	if !hmac.Equal([]byte(signature), []byte(responses[cognito.ParamPasswordClaimSignature])) {
+		return nil, &apiError{NotAuthorizedException, incorrectCredentials}
+	}
+
+	result, err := p.newTokens(s.user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cognito.RespondToAuthChallengeOutput{
+		AuthenticationResult: result,
+	}, nil
+}
+
+func (p *Pool) checkSecretHash(params map[string]string, username string) error {
+	if p.clientSecret == "" {
+		return nil
+	}
+
+	if params[cognito.ParamSecretHash] != cognito.SecretHash(p.clientSecret, username, p.clientID) {
+		return &apiError{NotAuthorizedException, "Unable to verify secret hash for client " + p.clientID}
+	}
+
+	return nil
+}
+
+func (p *Pool) computeU(s *session) []byte {
+	return cognito.ComputeU(p.srp, new(big.Int).SetBytes(s.xA), new(big.Int).SetBytes(s.server.B())).Bytes()
+}
+
+func (p *Pool) newSession(s *session) (string, error) {
+	b, err := randBytes(sessionSize)
+	if err != nil {
+		return "", err
+	}
+
+	id := base64.RawURLEncoding.EncodeToString(b)
+	now := p.now()
+	s.expires = now.Add(p.ttl)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Sessions that were never answered are removed here rather than by a
+	// background goroutine
+	for k, v := range p.sessions {
+		if now.After(v.expires) {
+			delete(p.sessions, k)
+		}
+	}
+
+	p.sessions[id] = s
+
+	return id, nil
+}
+
+func (p *Pool) newTokens(u *user) (*cognito.AuthenticationResult, error) {
+	tokens := make([]string, 3) //nolint:gomnd
+
+	for i := range tokens {
+		b, err := randBytes(tokenSize)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens[i] = base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.tokens[tokens[0]] = u.username
+
+	return &cognito.AuthenticationResult{
+		AccessToken:  tokens[0],
+		ExpiresIn:    expiresIn,
+		IDToken:      tokens[1],
+		RefreshToken: tokens[2],
+		TokenType:    "Bearer",
+	}, nil
+}
+
+type apiError struct {
+	Type    string
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return e.Type + ": " + e.Message
+}
+
+func writeError(w http.ResponseWriter, t, message string) {
+	w.Header().Set("Content-Type", cognito.ContentType)
+	w.WriteHeader(http.StatusBadRequest)
+
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"__type":  t,
+		"message": message,
+	})
+}
+
+func randBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, fmt.Errorf("unable to read random bytes: %w", err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,219 @@
+package cognitotest_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bodgit/srp/cognito"
+	"github.com/bodgit/srp/cognito/cognitotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	poolID   = "eu-west-1_Example"
+	clientID = "client"
+)
+
+//nolint:funlen
+func TestPool(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		name         string
+		secret       string
+		clientSecret string
+		clientID     string
+		username     string
+		password     string
+		err          string
+	}{
+		{
+			name:     "success",
+			clientID: clientID,
+			username: "alice",
+			password: "password123",
+		},
+		{
+			name:         "success with secret",
+			secret:       "secret",
+			clientSecret: "secret",
+			clientID:     clientID,
+			username:     "alice",
+			password:     "password123",
+		},
+		{
+			name:     "incorrect password",
+			clientID: clientID,
+			username: "alice",
+			password: "incorrect",
+			err:      cognitotest.NotAuthorizedException,
+		},
+		{
+			name:     "unknown user",
+			clientID: clientID,
+			username: "bob",
+			password: "password123",
+			err:      cognitotest.UserNotFoundException,
+		},
+		{
+			name:     "unknown client",
+			clientID: "unknown",
+			username: "alice",
+			password: "password123",
+			err:      cognitotest.ResourceNotFoundException,
+		},
+		{
+			name:         "incorrect secret",
+			secret:       "secret",
+			clientSecret: "incorrect",
+			clientID:     clientID,
+			username:     "alice",
+			password:     "password123",
+			err:          cognitotest.NotAuthorizedException,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := cognitotest.NewPool(poolID, clientID, cognitotest.ClientSecret(table.secret))
+			require.NoError(t, err)
+
+			_, err = pool.AddUser("alice", "password123")
+			require.NoError(t, err)
+
+			ts := pool.NewServer()
+			defer ts.Close()
+
+			c, err := cognito.NewClient(poolID, table.clientID,
+				cognito.Endpoint(ts.URL),
+				cognito.ClientSecret(table.clientSecret),
+				cognito.HTTPClient(ts.Client()))
+			require.NoError(t, err)
+
+			result, err := c.Login(context.Background(), table.username, table.password)
+			if table.err != "" {
+				var e *cognito.Error
+				if assert.True(t, errors.As(err, &e)) {
+					assert.Equal(t, table.err, e.Type)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			username, ok := pool.Username(result.AccessToken)
+			assert.True(t, ok)
+			assert.Equal(t, table.username, username)
+		})
+	}
+}
+
+func TestPoolAddUser(t *testing.T) {
+	t.Parallel()
+
+	pool, err := cognitotest.NewPool(poolID, clientID)
+	require.NoError(t, err)
+
+	_, err = pool.AddUser("alice", "password123")
+	require.NoError(t, err)
+
+	_, err = pool.AddUser("alice", "password123")
+	assert.ErrorIs(t, err, cognitotest.ErrUserExists)
+}
+
+func TestPoolExpiry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu  sync.Mutex
+		now = time.Now()
+	)
+
+	tables := []struct {
+		name  string
+		clock func() time.Time
+		err   string
+	}{
+		{
+			name: "session expired",
+			// Each request is two minutes after the last
+			clock: func() time.Time {
+				mu.Lock()
+				defer mu.Unlock()
+
+				now = now.Add(2 * time.Minute)
+
+				return now
+			},
+			err: cognitotest.NotAuthorizedException,
+		},
+		{
+			name: "timestamp too old",
+			clock: func() time.Time {
+				return time.Now().Add(time.Hour)
+			},
+			err: cognitotest.InvalidParameterException,
+		},
+		{
+			name: "timestamp too new",
+			clock: func() time.Time {
+				return time.Now().Add(-time.Hour)
+			},
+			err: cognitotest.InvalidParameterException,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := cognitotest.NewPool(poolID, clientID,
+				cognitotest.SessionTTL(time.Minute),
+				cognitotest.TimestampSkew(5*time.Minute),
+				cognitotest.Clock(table.clock))
+			require.NoError(t, err)
+
+			_, err = pool.AddUser("alice", "password123")
+			require.NoError(t, err)
+
+			ts := pool.NewServer()
+			defer ts.Close()
+
+			c, err := cognito.NewClient(poolID, clientID, cognito.Endpoint(ts.URL), cognito.HTTPClient(ts.Client()))
+			require.NoError(t, err)
+
+			_, err = c.Login(context.Background(), "alice", "password123")
+
+			var e *cognito.Error
+			if assert.True(t, errors.As(err, &e)) {
+				assert.Equal(t, table.err, e.Type)
+			}
+		})
+	}
+}
+
+func TestNewPool(t *testing.T) {
+	t.Parallel()
+
+	tables := []func(*cognitotest.Pool) error{
+		cognitotest.SessionTTL(0),
+		cognitotest.TimestampSkew(-time.Minute),
+		cognitotest.Clock(nil),
+	}
+
+	for _, table := range tables {
+		_, err := cognitotest.NewPool(poolID, clientID, table)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,135 @@
+package cognito
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bodgit/srp"
+)
+
+// Values of the ChallengeName field used for remembered devices.
+const (
+	ChallengeDeviceSRPAuth          = "DEVICE_SRP_AUTH"
+	ChallengeDevicePasswordVerifier = "DEVICE_PASSWORD_VERIFIER"
+)
+
+const devicePasswordSize = 40
+
+// Device holds the keys and the random password of a remembered device.
+type Device struct {
+	GroupKey string `json:"groupKey"`
+	Key      string `json:"key"`
+	Password string `json:"password"`
+}
+
+// DeviceSecretVerifierConfig holds the salt and verifier of a device in the
+// format expected by ConfirmDevice.
+type DeviceSecretVerifierConfig struct {
+	PasswordVerifier string `json:"PasswordVerifier"`
+	Salt             string `json:"Salt"`
+}
+
+// NewDevice generates a random password for the device identified by
+// groupKey and key, returning it along with the ISV to be passed to
+// NewDeviceSecretVerifierConfig. If rand is nil then crypto/rand.Reader is
+// used. Any options are passed to NewSRP.
+func NewDevice(groupKey, key string, rand io.Reader, options ...func(*srp.SRP) error) (*Device, *srp.ISV, error) {
+	b, err := randBytes(rand, devicePasswordSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s, err := NewSRP(options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d := &Device{
+		GroupKey: groupKey,
+		Key:      key,
+		Password: base64.StdEncoding.EncodeToString(b),
+	}
+
+	i, err := NewISV(s, d.identity(), []byte(d.Password), rand)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d, i, nil
+}
+
+// NewDeviceSecretVerifierConfig returns the salt and verifier in i in the
+// format expected by ConfirmDevice.
+func NewDeviceSecretVerifierConfig(i *srp.ISV) *DeviceSecretVerifierConfig {
+	return &DeviceSecretVerifierConfig{
+		PasswordVerifier: base64.StdEncoding.EncodeToString(Pad(i.Verifier)),
+		Salt:             base64.StdEncoding.EncodeToString(Pad(i.Salt)),
+	}
+}
+
+func (d *Device) identity() []byte {
+	return []byte(d.GroupKey + d.Key)
+}
+
+// DeviceAuth performs the client-side of the AWS Cognito DEVICE_SRP_AUTH
+// flow, which follows the PASSWORD_VERIFIER challenge for a remembered
+// device.
+type DeviceAuth struct {
+	device   *Device
+	username string
+	client   *srp.Client
+}
+
+// NewDeviceAuth returns a new DeviceAuth for the remembered device d. The
+// username should be the USER_ID_FOR_SRP parameter from the
+// PASSWORD_VERIFIER challenge. Any options are passed to NewSRP.
+func NewDeviceAuth(d *Device, username string, options ...func(*srp.SRP) error) (*DeviceAuth, error) {
+	s, err := NewSRP(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.NewClient(d.identity(), []byte(d.Password))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	return &DeviceAuth{
+		device:   d,
+		username: username,
+		client:   client,
+	}, nil
+}
+
+// SRPAuth returns the ChallengeResponses to pass to RespondToAuthChallenge
+// for the DEVICE_SRP_AUTH challenge.
+func (a *DeviceAuth) SRPAuth() map[string]string {
+	return map[string]string{
+		ParamUsername:  a.username,
+		ParamDeviceKey: a.device.Key,
+		ParamSRPA:      hex.EncodeToString(a.client.A()),
+	}
+}
+
+// PasswordVerifier computes the ChallengeResponses to pass to
+// RespondToAuthChallenge from the ChallengeParameters of the
+// DEVICE_PASSWORD_VERIFIER challenge. The timestamp is taken from now.
+func (a *DeviceAuth) PasswordVerifier(params map[string]string, now time.Time) (map[string]string, error) {
+	key, secretBlock, err := passwordVerifier(a.client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := FormatTimestamp(now)
+
+	return map[string]string{
+		ParamUsername:                 a.username,
+		ParamDeviceKey:                a.device.Key,
+		ParamPasswordClaimSecretBlock: params[ParamSecretBlock],
+		ParamTimestamp:                ts,
+		ParamPasswordClaimSignature:   ClaimSignature(key, a.device.identity(), secretBlock, ts),
+	}, nil
+}
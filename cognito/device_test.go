@@ -0,0 +1,89 @@
+package cognito_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/cognito"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vectors "github.com/bodgit/srp/internal/cognito"
+)
+
+func deviceRand() *bytes.Reader {
+	b := make([]byte, 0, 56)
+
+	for i := 0x00; i < 0x28; i++ {
+		b = append(b, byte(i))
+	}
+
+	for i := 0xf0; i < 0x100; i++ {
+		b = append(b, byte(i))
+	}
+
+	return bytes.NewReader(b)
+}
+
+func TestNewDevice(t *testing.T) {
+	t.Parallel()
+
+	d, i, err := cognito.NewDevice(vectors.DeviceGroupKey, vectors.DeviceKey, deviceRand())
+	require.NoError(t, err)
+
+	assert.Equal(t, &cognito.Device{
+		GroupKey: vectors.DeviceGroupKey,
+		Key:      vectors.DeviceKey,
+		Password: vectors.DevicePassword,
+	}, d)
+
+	assert.Equal(t, &cognito.DeviceSecretVerifierConfig{
+		PasswordVerifier: base64.StdEncoding.EncodeToString(vectors.DevicePasswordVerifier),
+		Salt:             base64.StdEncoding.EncodeToString(vectors.DeviceSalt),
+	}, cognito.NewDeviceSecretVerifierConfig(i))
+
+	_, _, err = cognito.NewDevice(vectors.DeviceGroupKey, vectors.DeviceKey, bytes.NewReader(nil))
+	assert.Error(t, err)
+}
+
+func TestDeviceAuth(t *testing.T) {
+	t.Parallel()
+
+	r := util.FixedRand(rfc5054.A, 384)
+
+	a, err := cognito.NewDeviceAuth(&cognito.Device{
+		GroupKey: vectors.DeviceGroupKey,
+		Key:      vectors.DeviceKey,
+		Password: vectors.DevicePassword,
+	}, vectors.UserID, srp.Rand(r))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		cognito.ParamUsername:  vectors.UserID,
+		cognito.ParamDeviceKey: vectors.DeviceKey,
+		cognito.ParamSRPA:      hex.EncodeToString(vectors.A),
+	}, a.SRPAuth())
+
+	responses, err := a.PasswordVerifier(map[string]string{
+		cognito.ParamSalt:        hex.EncodeToString(vectors.DeviceSalt),
+		cognito.ParamSRPB:        hex.EncodeToString(vectors.DeviceB),
+		cognito.ParamSecretBlock: vectors.SecretBlock,
+		cognito.ParamUsername:    vectors.UserID,
+		cognito.ParamDeviceKey:   vectors.DeviceKey,
+	}, time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		cognito.ParamUsername:                 vectors.UserID,
+		cognito.ParamDeviceKey:                vectors.DeviceKey,
+		cognito.ParamPasswordClaimSecretBlock: vectors.SecretBlock,
+		cognito.ParamTimestamp:                vectors.Timestamp,
+		cognito.ParamPasswordClaimSignature:   vectors.DeviceSignature,
+	}, responses)
+}
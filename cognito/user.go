@@ -0,0 +1,244 @@
+package cognito
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/bodgit/srp"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Names of the parameters used in the USER_SRP_AUTH flow.
+const (
+	ParamUsername                 = "USERNAME"
+	ParamSRPA                     = "SRP_A"
+	ParamSRPB                     = "SRP_B"
+	ParamSalt                     = "SALT"
+	ParamSecretBlock              = "SECRET_BLOCK"
+	ParamUserIDForSRP             = "USER_ID_FOR_SRP"
+	ParamSecretHash               = "SECRET_HASH"
+	ParamDeviceKey                = "DEVICE_KEY"
+	ParamPasswordClaimSecretBlock = "PASSWORD_CLAIM_SECRET_BLOCK"
+	ParamPasswordClaimSignature   = "PASSWORD_CLAIM_SIGNATURE"
+	ParamTimestamp                = "TIMESTAMP"
+)
+
+const (
+	keyInfo   = "Caldera Derived Key"
+	keySize   = 16
+	saltSize  = 16
+	timestamp = "Mon Jan 2 15:04:05 UTC 2006"
+)
+
+var (
+	// ErrInvalidPoolID means the user pool ID is not of the form
+	// <region>_<name>.
+	ErrInvalidPoolID = errors.New("invalid user pool ID")
+
+	// ErrMissingParameter means a required challenge parameter is
+	// missing.
+	ErrMissingParameter = errors.New("missing parameter")
+
+	errInvalidHex = errors.New("invalid hex value")
+)
+
+// User performs the client-side of the AWS Cognito USER_SRP_AUTH flow.
+type User struct {
+	poolName string
+	username string
+	client   *srp.Client
+}
+
+// NewUser returns a new User for the username and password in the user pool
+// identified by poolID. Any options are passed to NewSRP.
+func NewUser(poolID, username, password string, options ...func(*srp.SRP) error) (*User, error) {
+	_, poolName, err := SplitPoolID(poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewSRP(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.NewClient([]byte(poolName+username), []byte(password))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	return &User{
+		poolName: poolName,
+		username: username,
+		client:   client,
+	}, nil
+}
+
+// AuthParameters returns the AuthParameters to pass to InitiateAuth.
+func (u *User) AuthParameters() map[string]string {
+	return map[string]string{
+		ParamUsername: u.username,
+		ParamSRPA:     hex.EncodeToString(u.client.A()),
+	}
+}
+
+// PasswordVerifier computes the ChallengeResponses to pass to
+// RespondToAuthChallenge from the ChallengeParameters of the
+// PASSWORD_VERIFIER challenge. The timestamp is taken from now.
+func (u *User) PasswordVerifier(params map[string]string, now time.Time) (map[string]string, error) {
+	userID, ok := params[ParamUserIDForSRP]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParameter, ParamUserIDForSRP)
+	}
+
+	// The password is hashed with the user ID rather than the username
+	u.client.SetIdentity([]byte(u.poolName + userID))
+
+	key, secretBlock, err := passwordVerifier(u.client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := FormatTimestamp(now)
+
+	return map[string]string{
+		ParamUsername:                 userID,
+		ParamPasswordClaimSecretBlock: params[ParamSecretBlock],
+		ParamTimestamp:                ts,
+		ParamPasswordClaimSignature:   ClaimSignature(key, []byte(u.poolName+userID), secretBlock, ts),
+	}, nil
+}
+
+// NewISV creates a new ISV for the identity and password using s, which
+// should be created with NewSRP. Unlike srp.SRP.NewISV the salt is 16 bytes
+// and stored the way Cognito sends it back as a hex-encoded integer, so the
+// client computes the same X value. If rand is nil then crypto/rand.Reader is
+// used.
+func NewISV(s *srp.SRP, identity, password []byte, rand io.Reader) (*srp.ISV, error) {
+	b, err := randBytes(rand, saltSize)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := Pad(new(big.Int).SetBytes(b).Bytes())
+	x := ComputeX(s, identity, password, salt)
+
+	params, err := s.Params()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get parameters: %w", err)
+	}
+
+	return &srp.ISV{
+		Identity: identity,
+		Salt:     salt,
+		Verifier: new(big.Int).Exp(s.Group().G, x, s.Group().N).Bytes(),
+		Params:   params,
+	}, nil
+}
+
+// SplitPoolID splits a user pool ID into its region and name.
+func SplitPoolID(poolID string) (string, string, error) {
+	region, name, ok := strings.Cut(poolID, "_")
+	if !ok || region == "" || name == "" {
+		return "", "", ErrInvalidPoolID
+	}
+
+	return region, name, nil
+}
+
+// DeriveKey derives the 16-byte key used to sign the claim from the SRP
+// premaster secret S and U using HKDF with SHA-256.
+func DeriveKey(xS, u []byte) ([]byte, error) {
+	key := make([]byte, keySize)
+
+	r := hkdf.New(sha256.New, Pad(xS), Pad(u), []byte(keyInfo))
+	if _, err := io.ReadFull(r, key); err != nil {
+		return nil, fmt.Errorf("unable to derive key: %w", err)
+	}
+
+	return key, nil
+}
+
+// ClaimSignature returns the base64-encoded HMAC-SHA256 signature of the
+// claim. For a user the prefix is the pool name followed by the user ID, for
+// a device it is the device group key followed by the device key.
+func ClaimSignature(key, prefix, secretBlock []byte, timestamp string) string {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write(prefix)
+	_, _ = h.Write(secretBlock)
+	_, _ = h.Write([]byte(timestamp))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// FormatTimestamp formats t in the format Cognito expects, which is similar
+// to time.UnixDate but without padding the day of the month.
+func FormatTimestamp(t time.Time) string {
+	return t.UTC().Format(timestamp)
+}
+
+func passwordVerifier(client *srp.Client, params map[string]string) ([]byte, []byte, error) {
+	values := make(map[string]*big.Int, 2)
+
+	for _, name := range []string{ParamSalt, ParamSRPB} {
+		v, ok := params[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s", ErrMissingParameter, name)
+		}
+
+		i, ok := new(big.Int).SetString(v, 16)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w for %s", errInvalidHex, name)
+		}
+
+		values[name] = i
+	}
+
+	secretBlock, err := base64.StdEncoding.DecodeString(params[ParamSecretBlock])
+	if err != nil || len(secretBlock) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrMissingParameter, ParamSecretBlock)
+	}
+
+	if _, err := client.Compute(values[ParamSalt].Bytes(), values[ParamSRPB].Bytes()); err != nil {
+		return nil, nil, fmt.Errorf("unable to compute proof: %w", err)
+	}
+
+	xS, err := client.S()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to compute S: %w", err)
+	}
+
+	u, err := client.U()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to compute U: %w", err)
+	}
+
+	key, err := DeriveKey(xS, u)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, secretBlock, nil
+}
+
+func randBytes(r io.Reader, n int) ([]byte, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, fmt.Errorf("unable to read random bytes: %w", err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,154 @@
+package cognito_test
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/cognito"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vectors "github.com/bodgit/srp/internal/cognito"
+)
+
+func newUser(t *testing.T) *cognito.User {
+	t.Helper()
+
+	r := util.FixedRand(rfc5054.A, 384)
+
+	u, err := cognito.NewUser(vectors.PoolID, vectors.Username, vectors.Password, srp.Rand(r))
+	require.NoError(t, err)
+
+	return u
+}
+
+func TestUser(t *testing.T) {
+	t.Parallel()
+
+	u := newUser(t)
+
+	assert.Equal(t, map[string]string{
+		cognito.ParamUsername: vectors.Username,
+		cognito.ParamSRPA:     hex.EncodeToString(vectors.A),
+	}, u.AuthParameters())
+
+	responses, err := u.PasswordVerifier(map[string]string{
+		cognito.ParamSalt:         vectors.Salt,
+		cognito.ParamSRPB:         hex.EncodeToString(vectors.B),
+		cognito.ParamSecretBlock:  vectors.SecretBlock,
+		cognito.ParamUserIDForSRP: vectors.UserID,
+	}, time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		cognito.ParamUsername:                 vectors.UserID,
+		cognito.ParamPasswordClaimSecretBlock: vectors.SecretBlock,
+		cognito.ParamTimestamp:                vectors.Timestamp,
+		cognito.ParamPasswordClaimSignature:   vectors.Signature,
+	}, responses)
+}
+
+func TestUserMissingParameter(t *testing.T) {
+	t.Parallel()
+
+	params := map[string]string{
+		cognito.ParamSalt:         vectors.Salt,
+		cognito.ParamSRPB:         hex.EncodeToString(vectors.B),
+		cognito.ParamSecretBlock:  vectors.SecretBlock,
+		cognito.ParamUserIDForSRP: vectors.UserID,
+	}
+
+	for name := range params {
+		name := name
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			p := make(map[string]string, len(params))
+			for k, v := range params {
+				if k != name {
+					p[k] = v
+				}
+			}
+
+			_, err := newUser(t).PasswordVerifier(p, time.Now())
+			assert.ErrorIs(t, err, cognito.ErrMissingParameter)
+		})
+	}
+}
+
+func TestUserZeroParameter(t *testing.T) {
+	t.Parallel()
+
+	params := map[string]string{
+		cognito.ParamSecretBlock:  vectors.SecretBlock,
+		cognito.ParamUserIDForSRP: vectors.UserID,
+	}
+
+	// A zero salt is unusual but valid
+	params[cognito.ParamSalt], params[cognito.ParamSRPB] = "0", hex.EncodeToString(vectors.B)
+	_, err := newUser(t).PasswordVerifier(params, time.Now())
+	assert.NoError(t, err)
+
+	params[cognito.ParamSalt], params[cognito.ParamSRPB] = vectors.Salt, "0"
+	_, err = newUser(t).PasswordVerifier(params, time.Now())
+	assert.ErrorIs(t, err, srp.ErrInvalidPublicKey)
+
+	params[cognito.ParamSalt], params[cognito.ParamSRPB] = vectors.Salt, "xyz"
+	_, err = newUser(t).PasswordVerifier(params, time.Now())
+	assert.Error(t, err)
+}
+
+func TestNewUser(t *testing.T) {
+	t.Parallel()
+
+	_, err := cognito.NewUser("ABCdef123", vectors.Username, vectors.Password)
+	assert.ErrorIs(t, err, cognito.ErrInvalidPoolID)
+}
+
+func TestDeriveKey(t *testing.T) {
+	t.Parallel()
+
+	r := util.FixedRand(rfc5054.A, 384)
+	s := util.Must(cognito.NewSRP(srp.Rand(r)))
+
+	c, err := s.NewClient([]byte("ABCdef123"+vectors.UserID), []byte(vectors.Password))
+	require.NoError(t, err)
+
+	_, err = c.Compute(util.Must(hex.DecodeString(vectors.Salt)), vectors.B)
+	require.NoError(t, err)
+
+	key, err := cognito.DeriveKey(util.Must(c.S()), util.Must(c.U()))
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.Key, key)
+
+	_, err = cognito.DeriveKey(nil, nil)
+	assert.NoError(t, err)
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		t    time.Time
+		want string
+	}{
+		{
+			time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+			"Tue Jan 2 03:04:05 UTC 2024",
+		},
+		{
+			time.Date(2024, time.December, 25, 23, 59, 59, 0, time.FixedZone("", 3600)),
+			"Wed Dec 25 22:59:59 UTC 2024",
+		},
+	}
+
+	for _, table := range tables {
+		assert.Equal(t, table.want, cognito.FormatTimestamp(table.t))
+	}
+}
@@ -0,0 +1,223 @@
+package srp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// DeferredServer represents the server-side of an SRP session where the
+// server sends the salt and its public value before it has received the
+// client public value. This ordering is used by protocols such as Apple
+// HomeKit pair-setup. The client public value is instead provided along with
+// the M1 proof, which can only be checked once.
+//
+// It implements encoding.BinaryMarshaler so it can be serialized to
+// persistent storage between the two messages, it is restored with
+// SRP.UnmarshalDeferredServer.
+type DeferredServer struct {
+	srp      *SRP
+	isv      *ISV
+	v        *big.Int
+	server   Server
+	checked  bool
+	verified bool
+}
+
+const (
+	deferredChecked = 1 << iota
+	deferredVerified
+)
+
+var (
+	errServerNotReady    = errors.New("check the client proof first")
+	errServerChecked     = errors.New("client proof already checked")
+	errInvalidServerFlag = errors.New("invalid server state")
+	errNoSRP             = errors.New("no SRP set")
+)
+
+// NewDeferredServer creates a new DeferredServer using the ISV.
+func (s *SRP) NewDeferredServer(i *ISV) (*DeferredServer, error) {
+	server := &DeferredServer{
+		srp: s,
+		isv: i,
+		v:   s.Decode(i.Verifier),
+	}
+
+	if err := server.server.init(s, i, server.v); err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+// Salt returns the client salt value.
+func (s *DeferredServer) Salt() []byte {
+	return s.server.Salt()
+}
+
+// B returns the server public value.
+func (s *DeferredServer) B() []byte {
+	return s.server.B()
+}
+
+// Check takes the client public value and the M1 proof computed by the
+// client and compares the proof with the servers copy. If it is identical
+// then the servers M2 proof is returned to be sent back to the client.
+//
+// Check can only be called once, whether or not the proof is identical, so
+// that a client cannot make repeated guesses against the same server value.
+func (s *DeferredServer) Check(xA, m1 []byte) ([]byte, error) {
+	if s.checked {
+		return nil, errServerChecked
+	}
+
+	s.checked = true
+
+	a := s.srp.Decode(xA)
+	if new(big.Int).Mod(a, s.srp.Group().N).Sign() == 0 {
+		return nil, ErrInvalidPublicKey
+	}
+
+	if err := s.server.compute(s.srp, s.isv, s.v, a); err != nil {
+		return nil, err
+	}
+
+	m2, err := s.server.Check(m1)
+	if err != nil {
+		return nil, err
+	}
+
+	s.verified = true
+
+	return m2, nil
+}
+
+// Key returns the key shared with the client after s.Check() has verified the
+// client proof, otherwise an error is returned.
+func (s *DeferredServer) Key() ([]byte, error) {
+	if !s.verified {
+		return nil, errServerNotReady
+	}
+
+	return s.server.Key(), nil
+}
+
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface. The SRP is
+// not serialized.
+func (s *DeferredServer) MarshalBinary() ([]byte, error) {
+	isv, err := s.isv.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	b := new(bytes.Buffer)
+
+	if err := writeBytes(b, isv); err != nil {
+		return nil, err
+	}
+
+	if err := writeBytes(b, s.server.b.Bytes()); err != nil {
+		return nil, err
+	}
+
+	var flags byte
+
+	if s.checked {
+		flags |= deferredChecked
+	}
+
+	var xK []byte
+
+	if s.verified {
+		flags |= deferredVerified
+		xK = s.server.xK
+	}
+
+	_ = b.WriteByte(flags)
+
+	if err := writeBytes(b, xK); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalDeferredServer restores a DeferredServer serialized with
+// DeferredServer.MarshalBinary, using s for the remaining computations. The
+// SRP must match the one used to create it.
+func (s *SRP) UnmarshalDeferredServer(b []byte) (*DeferredServer, error) {
+	server := &DeferredServer{
+		srp: s,
+	}
+
+	if err := server.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+// UnmarshalBinary satisfies the encoding.BinaryUnmarshaler interface. The SRP
+// is not serialized so s must already have one, use
+// SRP.UnmarshalDeferredServer instead.
+func (s *DeferredServer) UnmarshalBinary(b []byte) error {
+	if s.srp == nil {
+		return errNoSRP
+	}
+
+	r := bytes.NewReader(b)
+
+	isv, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+
+	i := new(ISV)
+	if err := i.UnmarshalBinary(isv); err != nil {
+		return err
+	}
+
+	bb, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+
+	flags, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("unable to read state: %w", err)
+	}
+
+	if flags&^(deferredChecked|deferredVerified) != 0 || flags == deferredVerified {
+		return errInvalidServerFlag
+	}
+
+	xK, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+
+	if n, _ := io.CopyN(io.Discard, r, 1); n > 0 {
+		return ErrTrailingBytes
+	}
+
+	v := s.srp.Decode(i.Verifier)
+	k := new(big.Int).SetBytes(bb)
+
+	s.isv, s.v = i, v
+	s.server = Server{
+		b:    k,
+		xB:   s.srp.computeB(k, s.srp.multiplier(), v),
+		salt: i.Salt,
+		c:    s.srp.codec(),
+	}
+	s.checked, s.verified = flags&deferredChecked != 0, flags&deferredVerified != 0
+
+	if s.verified {
+		s.server.xK = xK
+	}
+
+	return nil
+}
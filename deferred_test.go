@@ -0,0 +1,159 @@
+package srp_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeferredServer(t *testing.T) {
+	t.Parallel()
+
+	s := newSRP()
+	i := util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))
+	server := util.Must(s.NewDeferredServer(i))
+
+	assert.Equal(t, i.Salt, server.Salt())
+	assert.LessOrEqual(t, new(big.Int).SetBytes(server.B()).BitLen(), s.Group().Size*8)
+
+	_, err := server.Key()
+	assert.Error(t, err)
+}
+
+func TestDeferredServer_Check(t *testing.T) {
+	t.Parallel()
+
+	s := newSRP()
+	i := util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))
+
+	server := util.Must(s.NewDeferredServer(i))
+
+	_, err := server.Check(s.Group().N.Bytes(), nil)
+	require.ErrorIs(t, err, srp.ErrInvalidPublicKey)
+
+	client := util.Must(s.NewClient(rfc5054.Identity, []byte("wrong")))
+
+	m1, err := client.Compute(server.Salt(), server.B())
+	require.NoError(t, err)
+
+	_, err = server.Check(client.A(), m1)
+	assert.Error(t, err)
+
+	// The key is not available after a failed check
+	_, err = server.Key()
+	assert.Error(t, err)
+}
+
+func TestDeferredServer_CheckOnce(t *testing.T) {
+	t.Parallel()
+
+	s := newSRP()
+	i := util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))
+	server := util.Must(s.NewDeferredServer(i))
+
+	client := util.Must(s.NewClient(rfc5054.Identity, []byte("wrong")))
+	m1 := util.Must(client.Compute(server.Salt(), server.B()))
+
+	_, err := server.Check(client.A(), m1)
+	require.Error(t, err)
+
+	// A second guess against the same B is rejected even if it is correct
+	client = util.Must(s.NewClient(rfc5054.Identity, rfc5054.Password))
+	m1 = util.Must(client.Compute(server.Salt(), server.B()))
+
+	_, err = server.Check(client.A(), m1)
+	assert.Error(t, err)
+
+	_, err = server.Key()
+	assert.Error(t, err)
+}
+
+func TestDeferredServer_MarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	s := newSRP()
+	i := util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))
+	server := util.Must(s.NewDeferredServer(i))
+
+	// Persist the server between sending B and receiving M1
+	b, err := server.MarshalBinary()
+	require.NoError(t, err)
+
+	restored, err := s.UnmarshalDeferredServer(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, server.Salt(), restored.Salt())
+	assert.Equal(t, server.B(), restored.B())
+
+	client := util.Must(s.NewClient(rfc5054.Identity, rfc5054.Password))
+	m1 := util.Must(client.Compute(restored.Salt(), restored.B()))
+
+	m2, err := restored.Check(client.A(), m1)
+	require.NoError(t, err)
+	require.NoError(t, client.Check(m2))
+
+	// The verified state and key survive a second round trip
+	b, err = restored.MarshalBinary()
+	require.NoError(t, err)
+
+	restored, err = s.UnmarshalDeferredServer(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, client.Key(), util.Must(restored.Key()))
+
+	_, err = restored.Check(client.A(), m1)
+	assert.Error(t, err)
+
+	_, err = s.UnmarshalDeferredServer(append(b, 0x00))
+	assert.ErrorIs(t, err, srp.ErrTrailingBytes)
+
+	var zero srp.DeferredServer
+	assert.Error(t, zero.UnmarshalBinary(b))
+}
+
+func TestDeferredHandshake(t *testing.T) {
+	t.Parallel()
+
+	s := newSRP()
+
+	i, err := s.NewISV(rfc5054.Identity, rfc5054.Password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := s.NewDeferredServer(i)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := s.NewClient(rfc5054.Identity, rfc5054.Password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m1, err := client.Compute(server.Salt(), server.B())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m2, err := server.Check(client.A(), m1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Check(m2); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := server.Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, client.Key(), key)
+}
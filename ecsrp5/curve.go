@@ -0,0 +1,176 @@
+package ecsrp5
+
+import (
+	"math/big"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/util"
+)
+
+const (
+	montgomeryA = 486662
+	curveSize   = 32
+	prime       = "7FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFED"
+)
+
+// Curve is an elliptic curve in short Weierstrass form, y^2 = x^3 + ax + b,
+// mapped from a Montgomery curve, v^2 = u^3 + Au^2 + u, by x = u + A/3. It
+// implements srp.AbstractGroup.
+//
+// Points are encoded as the big-endian Montgomery u-coordinate followed by a
+// byte holding the parity of the y-coordinate, as used by RouterOS. The point
+// at infinity encodes as all zeros. It cannot be decoded, nor can the point of
+// order two where u = 0.
+type Curve struct {
+	p, a, ma, shift *big.Int
+	g               *point
+	size            int
+}
+
+type point struct {
+	c    *Curve
+	x, y *big.Int // nil for the point at infinity
+}
+
+// Curve25519 returns the Weierstrass form of Curve25519 with the generator
+// mapped from the Montgomery generator, u = 9, with an even y-coordinate.
+func Curve25519() *Curve {
+	p := new(big.Int).SetBytes(util.Must(util.BytesFromHexString(prime)))
+	ma := big.NewInt(montgomeryA)
+	third := new(big.Int).ModInverse(big.NewInt(3), p)
+
+	c := &Curve{
+		p:     p,
+		ma:    ma,
+		shift: new(big.Int).Mod(new(big.Int).Mul(ma, third), p),
+		size:  curveSize,
+	}
+
+	// a = (3 - A^2) / 3
+	c.a = new(big.Int).Sub(big.NewInt(3), new(big.Int).Mul(ma, ma))
+	c.a.Mul(c.a, third).Mod(c.a, p)
+
+	c.g = util.Must(c.lift(big.NewInt(9), 0))
+
+	return c
+}
+
+// Generator returns the generator of the curve.
+func (c *Curve) Generator() srp.Element {
+	return c.g
+}
+
+// Identity returns the point at infinity.
+func (c *Curve) Identity() srp.Element {
+	return &point{c: c}
+}
+
+// Add returns the sum of the points a and b.
+func (c *Curve) Add(a, b srp.Element) srp.Element {
+	return c.add(a.(*point), b.(*point))
+}
+
+// ScalarMult returns the point e multiplied by k.
+func (c *Curve) ScalarMult(e srp.Element, k *big.Int) srp.Element {
+	// Montgomery ladder
+	r0, r1 := c.Identity().(*point), e.(*point)
+
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		if k.Bit(i) == 0 {
+			r0, r1 = c.add(r0, r0), c.add(r0, r1)
+		} else {
+			r0, r1 = c.add(r0, r1), c.add(r1, r1)
+		}
+	}
+
+	return r0
+}
+
+// Decode returns the point encoded by b. The u-coordinate is reduced modulo p
+// as RouterOS does.
+func (c *Curve) Decode(b []byte) (srp.Element, error) {
+	if len(b) != c.size+1 || b[c.size] > 1 {
+		return nil, srp.ErrInvalidElement
+	}
+
+	return c.lift(new(big.Int).SetBytes(b[:c.size]), uint(b[c.size]))
+}
+
+// ScalarSize returns the size of the field.
+func (c *Curve) ScalarSize() int {
+	return c.size
+}
+
+func (c *Curve) lift(u *big.Int, parity uint) (*point, error) {
+	u = new(big.Int).Mod(u, c.p)
+	if u.Sign() == 0 {
+		return nil, srp.ErrInvalidElement
+	}
+
+	// v^2 = u^3 + Au^2 + u
+	v2 := new(big.Int).Add(u, c.ma)
+	v2.Mul(v2, u).Add(v2, big.NewInt(1)).Mul(v2, u).Mod(v2, c.p)
+
+	y := new(big.Int).ModSqrt(v2, c.p)
+	if y == nil {
+		return nil, srp.ErrInvalidElement
+	}
+
+	if y.Bit(0) != parity {
+		y.Sub(c.p, y).Mod(y, c.p)
+	}
+
+	return &point{c, new(big.Int).Mod(new(big.Int).Add(u, c.shift), c.p), y}, nil
+}
+
+func (c *Curve) add(p, q *point) *point {
+	switch {
+	case p.x == nil:
+		return q
+	case q.x == nil:
+		return p
+	}
+
+	var l *big.Int
+
+	if p.x.Cmp(q.x) == 0 {
+		if s := new(big.Int).Add(p.y, q.y); s.Mod(s, c.p).Sign() == 0 {
+			return &point{c: c}
+		}
+
+		// l = (3x^2 + a) / 2y
+		l = new(big.Int).Mul(p.x, p.x)
+		l.Mul(l, big.NewInt(3)).Add(l, c.a)
+		l.Mul(l, new(big.Int).ModInverse(new(big.Int).Lsh(p.y, 1), c.p))
+	} else {
+		// l = (y2 - y1) / (x2 - x1)
+		l = new(big.Int).Sub(q.y, p.y)
+		l.Mul(l, new(big.Int).ModInverse(new(big.Int).Sub(q.x, p.x), c.p))
+	}
+
+	l.Mod(l, c.p)
+
+	// x3 = l^2 - x1 - x2, y3 = l(x1 - x3) - y1
+	x := new(big.Int).Mul(l, l)
+	x.Sub(x, p.x).Sub(x, q.x).Mod(x, c.p)
+
+	y := new(big.Int).Sub(p.x, x)
+	y.Mul(y, l).Sub(y, p.y).Mod(y, c.p)
+
+	return &point{c, x, y}
+}
+
+// Bytes returns the Montgomery u-coordinate and the parity of the
+// y-coordinate.
+func (p *point) Bytes() []byte {
+	b := make([]byte, p.c.size+1)
+	if p.x == nil {
+		return b
+	}
+
+	u := new(big.Int).Sub(p.x, p.c.shift)
+	u.Mod(u, p.c.p).FillBytes(b[:p.c.size])
+	b[p.c.size] = byte(p.y.Bit(0))
+
+	return b
+}
@@ -0,0 +1,46 @@
+package ecsrp5_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/ecsrp5"
+	vectors "github.com/bodgit/srp/internal/ecsrp5"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurve25519(t *testing.T) {
+	t.Parallel()
+
+	c := ecsrp5.Curve25519()
+	g := c.Generator()
+
+	// The generator is u = 9 with an even y-coordinate
+	assert.Equal(t, append(util.Pad(big.NewInt(9), 32), 0x00), g.Bytes())
+
+	// The generator has prime order 2^252 + 27742317777372353535851937790883648493
+	r := new(big.Int).SetBytes(util.Must(util.BytesFromHexString(
+		"1000000000000000000000000000000014DEF9DEA2F79CD65812631A5CF5D3ED")))
+	assert.Equal(t, c.Identity().Bytes(), c.ScalarMult(g, r).Bytes())
+
+	// 3G = G + 2G
+	assert.Equal(t, c.ScalarMult(g, big.NewInt(3)).Bytes(), c.Add(g, c.Add(g, g)).Bytes())
+
+	// Flipping the parity negates the point
+	e, err := c.Decode(vectors.XA)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.XA, e.Bytes())
+
+	n, err := c.Decode(append(append([]byte{}, vectors.XA[:32]...), vectors.XA[32]^1))
+	require.NoError(t, err)
+
+	assert.Equal(t, c.Identity().Bytes(), c.Add(e, n).Bytes())
+	assert.Equal(t, e.Bytes(), c.Add(e, c.Identity()).Bytes())
+
+	_, err = c.Decode(c.Identity().Bytes())
+	assert.ErrorIs(t, err, srp.ErrInvalidElement)
+}
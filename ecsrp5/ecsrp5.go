@@ -0,0 +1,328 @@
+// Package ecsrp5 implements EC-SRP5 from IEEE P1363.2 as used by MikroTik
+// RouterOS to authenticate Winbox and MAC Telnet sessions, where it is used
+// with SHA-256 and the Weierstrass form of Curve25519 returned by Curve25519.
+//
+// The protocol only needs the group operation so it is built on
+// srp.AbstractGroup, however the group must encode elements as a coordinate
+// followed by a parity byte, as Curve does, so that the verifier can be mapped
+// to an element and negated by flipping the parity.
+//
+// The client sends A, the server sends B and the salt, then the client sends
+// the M1 proof and the server responds with the M2 proof, the same as SRP-6a.
+// The ISVs created by this package do not record their parameters.
+//
+// This package is experimental. The test vectors are not taken from RouterOS,
+// Winbox or the MarginResearch reference code so it has not been verified
+// against a real router, and it may change once it has been.
+//
+// Warning: The arithmetic of Curve uses math/big and is not constant time.
+package ecsrp5
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bodgit/srp"
+)
+
+const saltSize = 16
+
+var (
+	errMismatchedProof = errors.New("mismatched proof")
+	errClientNotReady  = errors.New("set the server public key first")
+)
+
+// SRP manages the various computations used in the EC-SRP5 protocol.
+type SRP struct {
+	h    crypto.Hash
+	g    srp.AbstractGroup
+	rand io.Reader
+}
+
+// NewSRP returns a new SRP using the chosen hash and group along with any
+// options.
+func NewSRP(hash crypto.Hash, group srp.AbstractGroup, options ...func(*SRP) error) (*SRP, error) {
+	s := &SRP{
+		h:    hash,
+		g:    group,
+		rand: rand.Reader,
+	}
+
+	for _, option := range options {
+		if err := option(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Rand overrides the default source of randomness, crypto/rand.Reader, used
+// for generating salts and private values. It should only be used for
+// testing.
+func Rand(r io.Reader) func(*SRP) error {
+	return func(s *SRP) error {
+		if r == nil {
+			r = rand.Reader
+		}
+
+		s.rand = r
+
+		return nil
+	}
+}
+
+// SetRand overrides the default source of randomness, crypto/rand.Reader,
+// used for generating salts and private values. It should only be used for
+// testing.
+func (s *SRP) SetRand(r io.Reader) error {
+	return Rand(r)(s)
+}
+
+// NewISV creates a new ISV containing the identity, salt and verifier.
+func (s *SRP) NewISV(identity, password []byte) (*srp.ISV, error) {
+	salt, err := s.randBytes(saltSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &srp.ISV{
+		Identity: identity,
+		Salt:     salt,
+		Verifier: s.computeV(identity, password, salt).Bytes(),
+	}, nil
+}
+
+// NewClient creates a new Client using the identity and password.
+func (s *SRP) NewClient(identity, password []byte) (*Client, error) {
+	a, err := s.randBigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		s:        s,
+		identity: identity,
+		password: password,
+		a:        a,
+		xA:       s.g.ScalarMult(s.g.Generator(), a).Bytes(),
+	}, nil
+}
+
+// NewServer creates a new Server using the ISV and the client public value.
+func (s *SRP) NewServer(i *srp.ISV, xA []byte) (*Server, error) {
+	wA, err := s.g.Decode(xA)
+	if err != nil {
+		return nil, srp.ErrInvalidPublicKey
+	}
+
+	v, err := s.g.Decode(i.Verifier)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode verifier: %w", err)
+	}
+
+	b, err := s.randBigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	// B = bG + redp1(V)
+	xB := s.g.Add(s.g.ScalarMult(s.g.Generator(), b), s.redp1(i.Verifier, 0)).Bytes()
+	j := s.computeJ(xA, xB)
+
+	// Z = b(jV + A)
+	z, err := s.coordinate(s.g.ScalarMult(s.g.Add(s.g.ScalarMult(v, j), wA), b))
+	if err != nil {
+		return nil, err
+	}
+
+	server := &Server{
+		xB:   xB,
+		salt: i.Salt,
+		z:    z,
+	}
+	server.m1, server.m2 = s.computeM(j, z)
+
+	return server, nil
+}
+
+func (s *SRP) hashBytes(a ...[]byte) []byte {
+	h := s.h.New()
+
+	for _, z := range a {
+		_, _ = h.Write(z)
+	}
+
+	return h.Sum(nil)
+}
+
+func (s *SRP) hashInt(a ...[]byte) *big.Int {
+	return new(big.Int).SetBytes(s.hashBytes(a...))
+}
+
+func (s *SRP) randBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(s.rand, b); err != nil {
+		return nil, fmt.Errorf("unable to read random bytes: %w", err)
+	}
+
+	return b, nil
+}
+
+func (s *SRP) randBigInt() (*big.Int, error) {
+	b, err := s.randBytes(s.g.ScalarSize())
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (s *SRP) computeI(identity, password, salt []byte) *big.Int {
+	// i = H(s | H(I | ":" | P))
+	return s.hashInt(salt, s.hashBytes(identity, []byte(":"), password))
+}
+
+func (s *SRP) computeV(identity, password, salt []byte) srp.Element {
+	// V = iG
+	return s.g.ScalarMult(s.g.Generator(), s.computeI(identity, password, salt))
+}
+
+func (s *SRP) computeJ(xA, xB []byte) *big.Int {
+	// j = H(A | B) using just the coordinates
+	return s.hashInt(xA[:len(xA)-1], xB[:len(xB)-1])
+}
+
+func (s *SRP) computeM(j *big.Int, z []byte) ([]byte, []byte) {
+	// M1 = H(j | Z), M2 = H(j | M1 | Z)
+	jb := s.pad(j)
+	m1 := s.hashBytes(jb, z)
+
+	return m1, s.hashBytes(jb, m1, z)
+}
+
+// redp1 maps the coordinate of an encoded element to an element by hashing
+// it until it is the coordinate of another element, the parity selects the
+// element or its inverse.
+func (s *SRP) redp1(b []byte, parity byte) srp.Element {
+	x := s.hashInt(b[:len(b)-1])
+
+	for {
+		e, err := s.g.Decode(append(s.hashBytes(s.pad(x)), parity))
+		if err == nil {
+			return e
+		}
+
+		x.Add(x, big.NewInt(1))
+	}
+}
+
+// coordinate returns the coordinate of e, which must not be the identity.
+func (s *SRP) coordinate(e srp.Element) ([]byte, error) {
+	b := e.Bytes()
+	if bytes.Equal(b, s.g.Identity().Bytes()) {
+		return nil, srp.ErrInvalidPublicKey
+	}
+
+	return b[:len(b)-1], nil
+}
+
+func (s *SRP) pad(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, s.h.Size()))
+}
+
+// Client represents the client-side of an EC-SRP5 session.
+type Client struct {
+	s                  *SRP
+	identity, password []byte
+	a                  *big.Int
+	xA, z, m2          []byte
+}
+
+// A returns the client public value.
+func (c *Client) A() []byte {
+	return c.xA
+}
+
+// Compute takes the salt and public value provided by the server and computes
+// the proofs and shared key. It returns the M1 proof to be sent to the server.
+func (c *Client) Compute(salt, xB []byte) ([]byte, error) {
+	wB, err := c.s.g.Decode(xB)
+	if err != nil {
+		return nil, srp.ErrInvalidPublicKey
+	}
+
+	i := c.s.computeI(c.identity, c.password, salt)
+	v := c.s.g.ScalarMult(c.s.g.Generator(), i).Bytes()
+	j := c.s.computeJ(c.xA, xB)
+
+	// Z = (ij + a)(B - redp1(V))
+	z, err := c.s.coordinate(c.s.g.ScalarMult(c.s.g.Add(wB, c.s.redp1(v, 1)),
+		new(big.Int).Add(new(big.Int).Mul(i, j), c.a)))
+	if err != nil {
+		return nil, err
+	}
+
+	m1, m2 := c.s.computeM(j, z)
+	c.z, c.m2 = z, m2
+
+	return m1, nil
+}
+
+// Check compares the M2 proof computed by the server with the clients copy.
+// It fails if c.Compute() has not been called successfully.
+func (c *Client) Check(m2 []byte) error {
+	if c.m2 == nil {
+		return errClientNotReady
+	}
+
+	if subtle.ConstantTimeCompare(m2, c.m2) != 1 {
+		return errMismatchedProof
+	}
+
+	return nil
+}
+
+// Key returns the shared secret Z from which RouterOS derives its session
+// keys.
+func (c *Client) Key() []byte {
+	return c.z
+}
+
+// Server represents the server-side of an EC-SRP5 session.
+type Server struct {
+	xB, salt, z, m1, m2 []byte
+}
+
+// Salt returns the client salt value.
+func (s *Server) Salt() []byte {
+	return s.salt
+}
+
+// B returns the server public value.
+func (s *Server) B() []byte {
+	return s.xB
+}
+
+// Check compares the M1 proof computed by the client with the servers copy.
+// If it is identical then the servers M2 proof is returned to be sent back to
+// the client.
+func (s *Server) Check(m1 []byte) ([]byte, error) {
+	if subtle.ConstantTimeCompare(m1, s.m1) != 1 {
+		return nil, errMismatchedProof
+	}
+
+	return s.m2, nil
+}
+
+// Key returns the shared secret Z from which RouterOS derives its session
+// keys.
+func (s *Server) Key() []byte {
+	return s.z
+}
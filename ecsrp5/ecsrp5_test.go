@@ -0,0 +1,170 @@
+package ecsrp5_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	_ "crypto/sha256"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/ecsrp5"
+	vectors "github.com/bodgit/srp/internal/ecsrp5"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	s, err := ecsrp5.NewSRP(crypto.SHA256, ecsrp5.Curve25519(), ecsrp5.Rand(bytes.NewReader(vectors.Salt)))
+	require.NoError(t, err)
+
+	i, err := s.NewISV(vectors.Identity, vectors.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.Salt, i.Salt)
+	assert.Equal(t, vectors.Verifier, i.Verifier)
+
+	require.NoError(t, s.SetRand(bytes.NewReader(vectors.PrivateA)))
+
+	client, err := s.NewClient(vectors.Identity, vectors.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.XA, client.A())
+
+	require.NoError(t, s.SetRand(bytes.NewReader(vectors.PrivateB)))
+
+	server, err := s.NewServer(i, client.A())
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.XB, server.B())
+	assert.Equal(t, vectors.Z, server.Key())
+
+	m1, err := client.Compute(server.Salt(), server.B())
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.Z, client.Key())
+	assert.Equal(t, vectors.M1, m1)
+
+	m2, err := server.Check(m1)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.M2, m2)
+	assert.NoError(t, client.Check(m2))
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := srp.NewMemoryStore()
+
+	s := util.Must(ecsrp5.NewSRP(crypto.SHA256, ecsrp5.Curve25519()))
+	require.NoError(t, store.Put(ctx, util.Must(s.NewISV(vectors.Identity, vectors.Password))))
+
+	tables := []struct {
+		name     string
+		password []byte
+		err      bool
+	}{
+		{
+			"correct password",
+			vectors.Password,
+			false,
+		},
+		{
+			"incorrect password",
+			[]byte("incorrect"),
+			true,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := s.NewClient(vectors.Identity, table.password)
+			require.NoError(t, err)
+
+			i, err := store.Get(ctx, vectors.Identity)
+			require.NoError(t, err)
+
+			server, err := s.NewServer(i, client.A())
+			require.NoError(t, err)
+
+			m1, err := client.Compute(server.Salt(), server.B())
+			require.NoError(t, err)
+
+			m2, err := server.Check(m1)
+			if table.err {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NoError(t, client.Check(m2))
+			assert.Equal(t, client.Key(), server.Key())
+		})
+	}
+}
+
+func TestInvalidPublicKey(t *testing.T) {
+	t.Parallel()
+
+	s := util.Must(ecsrp5.NewSRP(crypto.SHA256, ecsrp5.Curve25519()))
+	i := &srp.ISV{
+		Identity: vectors.Identity,
+		Salt:     vectors.Salt,
+		Verifier: vectors.Verifier,
+	}
+
+	tables := []struct {
+		name string
+		key  []byte
+	}{
+		{
+			"short",
+			vectors.XA[1:],
+		},
+		{
+			"invalid parity",
+			append(append([]byte{}, vectors.XA[:32]...), 0x02),
+		},
+		{
+			"zero",
+			make([]byte, 33),
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := s.NewServer(i, table.key)
+			assert.ErrorIs(t, err, srp.ErrInvalidPublicKey)
+
+			client := util.Must(s.NewClient(vectors.Identity, vectors.Password))
+
+			_, err = client.Compute(vectors.Salt, table.key)
+			assert.ErrorIs(t, err, srp.ErrInvalidPublicKey)
+		})
+	}
+}
+
+func TestClientCheck(t *testing.T) {
+	t.Parallel()
+
+	s := util.Must(ecsrp5.NewSRP(crypto.SHA256, ecsrp5.Curve25519()))
+	client := util.Must(s.NewClient(vectors.Identity, vectors.Password))
+
+	// There is no proof to compare before Compute
+	assert.Error(t, client.Check(nil))
+	assert.Error(t, client.Check(make([]byte, crypto.SHA256.Size())))
+}
@@ -0,0 +1,89 @@
+package srp
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bodgit/srp/internal/util"
+)
+
+// Element is an element of an AbstractGroup.
+type Element interface {
+	// Bytes returns the encoding of the element.
+	Bytes() []byte
+}
+
+// AbstractGroup is implemented by groups that only need the group operation,
+// written additively, such as Group or an elliptic curve group. SRP-6a also
+// needs integer multiplication modulo N so it only works with Group, however
+// protocols such as EC-SRP5 can be built on any AbstractGroup.
+//
+// Elements passed to the methods must have been returned by the same group.
+type AbstractGroup interface {
+	// Generator returns the generator of the group.
+	Generator() Element
+
+	// Identity returns the identity element of the group.
+	Identity() Element
+
+	// Add returns the result of applying the group operation to a and b.
+	Add(a, b Element) Element
+
+	// ScalarMult returns the result of applying the group operation to e
+	// with itself k times.
+	ScalarMult(e Element, k *big.Int) Element
+
+	// Decode returns the element encoded by b, or ErrInvalidElement.
+	Decode(b []byte) (Element, error)
+
+	// ScalarSize returns the size in bytes of random private values.
+	ScalarSize() int
+}
+
+// ErrInvalidElement means the bytes do not encode an element of the group.
+var ErrInvalidElement = errors.New("invalid group element")
+
+type groupElement struct {
+	x    *big.Int
+	size int
+}
+
+func (e groupElement) Bytes() []byte {
+	return util.Pad(e.x, e.size)
+}
+
+// Generator returns g as an Element.
+func (g *Group) Generator() Element {
+	return groupElement{g.G, g.Size}
+}
+
+// Identity returns 1 as an Element.
+func (g *Group) Identity() Element {
+	return groupElement{big.NewInt(1), g.Size}
+}
+
+// Add returns a * b % N.
+func (g *Group) Add(a, b Element) Element {
+	return groupElement{new(big.Int).Mod(new(big.Int).Mul(a.(groupElement).x, b.(groupElement).x), g.N), g.Size}
+}
+
+// ScalarMult returns e ^ k % N.
+func (g *Group) ScalarMult(e Element, k *big.Int) Element {
+	return groupElement{new(big.Int).Exp(e.(groupElement).x, k, g.N), g.Size}
+}
+
+// Decode returns the big-endian number encoded by b as an Element. It must be
+// between 1 and N - 1.
+func (g *Group) Decode(b []byte) (Element, error) {
+	x := new(big.Int).SetBytes(b)
+	if x.Sign() == 0 || x.Cmp(g.N) >= 0 {
+		return nil, ErrInvalidElement
+	}
+
+	return groupElement{x, g.Size}, nil
+}
+
+// ScalarSize returns the size of the group.
+func (g *Group) ScalarSize() int {
+	return g.Size
+}
@@ -0,0 +1,53 @@
+package srp_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupElement(t *testing.T) {
+	t.Parallel()
+
+	var g srp.AbstractGroup = util.Must(srp.GetGroup(1024))
+
+	// g^3 = g * g^2
+	assert.Equal(t, g.ScalarMult(g.Generator(), big.NewInt(3)).Bytes(),
+		g.Add(g.Generator(), g.Add(g.Generator(), g.Generator())).Bytes())
+	assert.Equal(t, g.Generator().Bytes(), g.Add(g.Generator(), g.Identity()).Bytes())
+	assert.Len(t, g.Generator().Bytes(), g.ScalarSize())
+
+	e, err := g.Decode(g.Generator().Bytes())
+	require.NoError(t, err)
+
+	assert.Equal(t, g.Generator().Bytes(), e.Bytes())
+
+	tables := []struct {
+		name string
+		b    []byte
+	}{
+		{
+			"zero",
+			[]byte{0x00},
+		},
+		{
+			"N",
+			util.Must(srp.GetGroup(1024)).N.Bytes(),
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := g.Decode(table.b)
+			assert.ErrorIs(t, err, srp.ErrInvalidElement)
+		})
+	}
+}
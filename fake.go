@@ -0,0 +1,61 @@
+package srp
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// NewFakeISV returns a fake ISV for an identity that does not exist so that
+// a server can respond with a plausible salt and public value rather than
+// revealing that the identity is unknown. The salt and verifier are derived
+// from secret and the identity so repeated requests for the same identity
+// return the same salt. The salt is the same size as one created by NewISV
+// and no password will match the verifier so authentication always fails when
+// the client proof is checked.
+//
+// The secret should be randomly generated, at least as long as the hash
+// output, and kept for the lifetime of the server as changing it changes the
+// fake salts.
+//
+// The fake ISV uses the parameters of s so it can be told apart from a real
+// ISV using different parameters, such as one waiting to be upgraded. The
+// Authenticator can choose from several parameters with FakeParams.
+func (s *SRP) NewFakeISV(secret, identity []byte) (*ISV, error) {
+	salt := make([]byte, s.saltSize())
+	if err := s.fakeBytes(secret, "salt", identity, salt); err != nil {
+		return nil, err
+	}
+
+	// Deriving the verifier directly avoids the cost of the X function and
+	// the exponentiation which would otherwise make the response slower
+	// than for a real identity
+	v := make([]byte, s.Group().Size)
+	if err := s.fakeBytes(secret, "verifier", identity, v); err != nil {
+		return nil, err
+	}
+
+	params, err := s.Params()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ISV{
+		Identity: identity,
+		Salt:     salt,
+		Verifier: s.Encode(new(big.Int).Mod(new(big.Int).SetBytes(v), s.Group().N)),
+		Params:   params,
+	}, nil
+}
+
+func (s *SRP) fakeBytes(secret []byte, label string, identity, b []byte) error {
+	info := append([]byte("srp fake "+label+":"), identity...)
+
+	if _, err := io.ReadFull(hkdf.New(s.h.New, secret, nil, info), b); err != nil {
+		return fmt.Errorf("unable to derive fake %s: %w", label, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package srp_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals
+var fakeSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func TestSRP_NewFakeISV(t *testing.T) {
+	t.Parallel()
+
+	s := newSRP()
+
+	i := util.Must(s.NewFakeISV(fakeSecret, rfc5054.Identity))
+	genuine := util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))
+
+	assert.Equal(t, rfc5054.Identity, i.Identity)
+	assert.Len(t, i.Salt, len(genuine.Salt))
+	assert.Equal(t, genuine.Params, i.Params)
+	assert.Equal(t, -1, new(big.Int).SetBytes(i.Verifier).Cmp(s.Group().N))
+
+	// The same identity and secret always produce the same ISV
+	assert.Equal(t, i, util.Must(s.NewFakeISV(fakeSecret, rfc5054.Identity)))
+
+	assert.NotEqual(t, i.Salt, util.Must(s.NewFakeISV(fakeSecret, []byte("bob"))).Salt)
+	assert.NotEqual(t, i.Salt, util.Must(s.NewFakeISV([]byte("secret"), rfc5054.Identity)).Salt)
+}
+
+func TestAuthenticator_FakeISVs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newSRP()
+	a, _ := newAuthenticator(t, s, srp.FakeISVs(fakeSecret))
+
+	genuine := util.Must(a.Start(ctx, rfc5054.Identity, rfc5054.XA))
+
+	client := util.Must(s.NewClient([]byte("bob"), rfc5054.Password))
+
+	challenge, err := a.Start(ctx, []byte("bob"), client.A())
+	require.NoError(t, err)
+
+	assert.Len(t, challenge.Salt, len(genuine.Salt))
+	assert.Equal(t, genuine.Params, challenge.Params)
+	assert.LessOrEqual(t, new(big.Int).SetBytes(challenge.B).BitLen(), s.Group().Size*8)
+	assert.Equal(t, challenge.Salt, util.Must(a.Start(ctx, []byte("bob"), client.A())).Salt)
+
+	m1, err := client.Compute(challenge.Salt, challenge.B)
+	require.NoError(t, err)
+
+	_, err = a.Finish(ctx, challenge.ID, m1)
+	assert.Error(t, err)
+}
+
+func TestAuthenticator_FakeParams(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	old, target := util.Must(newSRP().Params()), util.Must(newTarget().Params())
+
+	a, _ := newAuthenticator(t, newTarget(), srp.FakeISVs(fakeSecret), srp.FakeParams(old, target))
+
+	seen := map[bool]bool{}
+
+	for _, identity := range []string{"bob", "carol", "dave", "eve", "frank", "grace", "heidi", "ivan"} {
+		s := util.Must(srp.NewSRPFromParams(target))
+		client := util.Must(s.NewClient([]byte(identity), rfc5054.Password))
+
+		challenge, err := a.Start(ctx, []byte(identity), client.A())
+		require.NoError(t, err)
+
+		// The same identity is always given the same parameters
+		assert.Equal(t, challenge.Params, util.Must(a.Start(ctx, []byte(identity), client.A())).Params)
+
+		seen[challenge.Params.Equal(old)] = true
+	}
+
+	assert.Len(t, seen, 2)
+
+	_, err := srp.NewAuthenticator(newSRP(), srp.NewMemoryStore(), srp.FakeParams())
+	assert.Error(t, err)
+}
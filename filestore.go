@@ -0,0 +1,199 @@
+package srp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	fileStoreExt  = ".isv"
+	fileStorePerm = 0o700
+)
+
+// FileStore is a VerifierStore that holds each ISV in a separate file within
+// a directory. The file name is the hex-encoded SHA-256 digest of the
+// identity so it is a fixed length whatever the identity. Updates are atomic
+// as each ISV is written to a temporary file which is then renamed over any
+// existing file, and the directory is synced afterwards so the rename is
+// durable. Swap is only atomic with respect to other calls on the same
+// FileStore.
+type FileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileStore returns a new FileStore using the directory dir, creating it if
+// necessary.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, fileStorePerm); err != nil {
+		return nil, fmt.Errorf("unable to create directory: %w", err)
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+// Get returns the ISV for the identity, or ErrNotFound.
+func (f *FileStore) Get(_ context.Context, identity []byte) (*ISV, error) {
+	b, err := os.ReadFile(f.path(identity))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("unable to read ISV: %w", err)
+	}
+
+	i := new(ISV)
+	if err := i.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(i.Identity, identity) {
+		return nil, ErrNotFound
+	}
+
+	return i, nil
+}
+
+// Put stores the ISV, replacing any existing ISV for the same identity.
+func (f *FileStore) Put(_ context.Context, i *ISV) error {
+	b, err := i.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.write(i.Identity, b)
+}
+
+// Swap replaces the stored ISV old with i, or returns ErrISVChanged if the ISV
+// stored for the identity is no longer old.
+func (f *FileStore) Swap(_ context.Context, old, i *ISV) error {
+	ob, err := old.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	b, err := i.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	current, err := os.ReadFile(f.path(old.Identity))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("unable to read ISV: %w", err)
+	}
+
+	if !bytes.Equal(old.Identity, i.Identity) || !bytes.Equal(current, ob) {
+		return ErrISVChanged
+	}
+
+	return f.write(i.Identity, b)
+}
+
+func (f *FileStore) write(identity, b []byte) (err error) {
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("unable to create temporary file: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(b); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("unable to write ISV: %w", err)
+	}
+
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("unable to sync ISV: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("unable to close ISV: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), f.path(identity)); err != nil {
+		return fmt.Errorf("unable to rename ISV: %w", err)
+	}
+
+	return syncDir(f.dir)
+}
+
+// Delete removes the ISV for the identity, or returns ErrNotFound.
+func (f *FileStore) Delete(_ context.Context, identity []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.Remove(f.path(identity)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrNotFound
+		}
+
+		return fmt.Errorf("unable to remove ISV: %w", err)
+	}
+
+	return syncDir(f.dir)
+}
+
+// List returns the identities of all stored ISVs in lexical order.
+func (f *FileStore) List(_ context.Context) ([][]byte, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read directory: %w", err)
+	}
+
+	identities := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, fileStoreExt) {
+			continue
+		}
+
+		if digest, err := hex.DecodeString(strings.TrimSuffix(name, fileStoreExt)); err != nil || len(digest) != sha256.Size {
+			continue
+		}
+
+		// The identity is only recorded in the ISV
+		b, err := os.ReadFile(filepath.Join(f.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ISV: %w", err)
+		}
+
+		i := new(ISV)
+		if err := i.UnmarshalBinary(b); err != nil {
+			return nil, err
+		}
+
+		identities = append(identities, string(i.Identity))
+	}
+
+	return sortIdentities(identities), nil
+}
+
+func (f *FileStore) path(identity []byte) string {
+	digest := sha256.Sum256(identity)
+
+	return filepath.Join(f.dir, hex.EncodeToString(digest[:])+fileStoreExt)
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package srp
+
+import (
+	"fmt"
+	"os"
+)
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("unable to open directory: %w", err)
+	}
+
+	if err = d.Sync(); err != nil {
+		_ = d.Close()
+
+		return fmt.Errorf("unable to sync directory: %w", err)
+	}
+
+	if err = d.Close(); err != nil {
+		return fmt.Errorf("unable to close directory: %w", err)
+	}
+
+	return nil
+}
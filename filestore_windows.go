@@ -0,0 +1,6 @@
+package srp
+
+// syncDir does nothing as Windows does not support syncing a directory.
+func syncDir(_ string) error {
+	return nil
+}
@@ -0,0 +1,270 @@
+package srp
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type groupGenerator struct {
+	workers  int
+	progress func(uint64)
+}
+
+// GenerateOption is an option for GenerateGroup.
+type GenerateOption func(*groupGenerator) error
+
+type lockedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+// searchWindow is how far the search moves from each random starting point.
+const searchWindow = 1 << 16
+
+// smallPrimes are used to sieve candidates before the primality tests.
+//
+//nolint:gochecknoglobals
+var smallPrimes = func() []int64 {
+	var primes []int64
+
+	for p := int64(3); p < 1<<14; p += 2 {
+		if big.NewInt(p).ProbablyPrime(0) {
+			primes = append(primes, p)
+		}
+	}
+
+	return primes
+}()
+
+// minGenerateBits keeps candidates well above the small primes and the
+// search window.
+const minGenerateBits = 64
+
+var (
+	errInvalidBits    = fmt.Errorf("bits must be a multiple of 8 and at least %d", minGenerateBits)
+	errInvalidWorkers = errors.New("number of workers must be positive")
+)
+
+// GenerateWorkers sets the number of goroutines used by GenerateGroup. The
+// default is runtime.NumCPU.
+func GenerateWorkers(n int) GenerateOption {
+	return func(g *groupGenerator) error {
+		if n < 1 {
+			return errInvalidWorkers
+		}
+
+		g.workers = n
+
+		return nil
+	}
+}
+
+// GenerateProgress sets a function that is called by GenerateGroup with the
+// total number of candidates tested so far. It may be called concurrently.
+func GenerateProgress(f func(uint64)) GenerateOption {
+	return func(g *groupGenerator) error {
+		g.progress = f
+
+		return nil
+	}
+}
+
+// GenerateGroup returns a new Group with a random safe prime N of the chosen
+// number of bits, and the smallest generator g of the whole multiplicative
+// group, using r as the source of randomness along with any options. If r is
+// nil then crypto/rand.Reader is used. Generating large groups is slow so it
+// can be cancelled with ctx. The number of bits must be a multiple of 8 so
+// that the group can be read back with NewGroup, and the result passes
+// Group.Validate if bits is at least MinGroupBits.
+func GenerateGroup(ctx context.Context, bits int, r io.Reader, options ...GenerateOption) (*Group, error) {
+	if bits < minGenerateBits || bits%8 != 0 {
+		return nil, errInvalidBits
+	}
+
+	gg := &groupGenerator{
+		workers: runtime.NumCPU(),
+	}
+
+	for _, option := range options {
+		if err := option(gg); err != nil {
+			return nil, err
+		}
+	}
+
+	if r == nil {
+		r = rand.Reader
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		lr     = &lockedReader{r: r}
+		tested uint64
+		wg     sync.WaitGroup
+		once   sync.Once
+		n      *big.Int
+		err    error
+	)
+
+	// The first worker to finish, successfully or not, stops the others
+	for i := 0; i < gg.workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			p, e := gg.search(ctx, lr, bits, &tested)
+
+			once.Do(func() {
+				n, err = p, e
+
+				cancel()
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Group{
+		G:    generator(n),
+		N:    n,
+		Size: bits >> 3,
+	}, nil
+}
+
+// Hex returns N in the format read by NewGroup.
+func (g *Group) Hex() string {
+	s := strings.ToUpper(fmt.Sprintf("%0*x", g.Size<<1, g.N))
+
+	var b strings.Builder
+
+	// Words of 8 digits, 7 to a line
+	for i := 0; i < len(s); i += 8 {
+		if i > 0 {
+			if i%56 == 0 {
+				_ = b.WriteByte('\n')
+			} else {
+				_ = b.WriteByte(' ')
+			}
+		}
+
+		end := i + 8
+		if end > len(s) {
+			end = len(s)
+		}
+
+		_, _ = b.WriteString(s[i:end])
+	}
+
+	_ = b.WriteByte('\n')
+
+	return b.String()
+}
+
+// search tests candidates from random starting points until it finds a safe
+// prime of the chosen number of bits, an error occurs or ctx is done.
+func (gg *groupGenerator) search(ctx context.Context, r *lockedReader, bits int, tested *uint64) (*big.Int, error) {
+	b := make([]byte, bits>>3)
+	residues := make([]int64, len(smallPrimes))
+	one := big.NewInt(1)
+
+	for ctx.Err() == nil {
+		if err := r.read(b); err != nil {
+			return nil, err
+		}
+
+		// Make q exactly bits-1 bits with the top two bits set, so N is
+		// exactly bits, and odd
+		q := new(big.Int).SetBytes(b)
+		q.Rsh(q, uint(len(b)<<3-bits+1))
+		q.SetBit(q, bits-2, 1).SetBit(q, bits-3, 1).SetBit(q, 0, 1)
+
+		m := new(big.Int)
+		for i, p := range smallPrimes {
+			residues[i] = m.Mod(q, big.NewInt(p)).Int64()
+		}
+
+		// Test q + delta for a window of deltas, sieving with the
+		// residues to avoid most of the big.Int arithmetic
+		for delta := int64(0); delta < searchWindow && ctx.Err() == nil; delta += 2 {
+			if !sieve(residues, delta) {
+				continue
+			}
+
+			count := atomic.AddUint64(tested, 1)
+			if gg.progress != nil {
+				gg.progress(count)
+			}
+
+			c := new(big.Int).Add(q, big.NewInt(delta))
+			if c.BitLen() != bits-1 {
+				break
+			}
+
+			n := new(big.Int).Lsh(c, 1)
+			n.Add(n, one)
+
+			// Cheap Fermat test before the full tests
+			if new(big.Int).Exp(big.NewInt(2), new(big.Int).Sub(n, one), n).Cmp(one) != 0 {
+				continue
+			}
+
+			if c.ProbablyPrime(primeRounds) && n.ProbablyPrime(primeRounds) {
+				return n, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unable to generate group: %w", ctx.Err())
+}
+
+// sieve reports whether neither q + delta nor 2(q + delta) + 1 has a small
+// prime factor, given the residues of q.
+func sieve(residues []int64, delta int64) bool {
+	for i, p := range smallPrimes {
+		r := (residues[i] + delta) % p
+		if r == 0 || r == (p-1)>>1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// generator returns the smallest generator of the multiplicative group of the
+// safe prime n, which is any element other than 1 and n - 1 that is not in
+// the subgroup of order q = (n - 1) / 2.
+func generator(n *big.Int) *big.Int {
+	q := new(big.Int).Rsh(n, 1)
+	one := big.NewInt(1)
+
+	for g := big.NewInt(2); ; g.Add(g, one) {
+		if new(big.Int).Exp(g, q, n).Cmp(one) != 0 {
+			return g
+		}
+	}
+}
+
+func (r *lockedReader) read(b []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return fmt.Errorf("unable to read random bytes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package srp_test
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateGroup(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		name string
+		bits int
+		slow bool
+		err  error
+	}{
+		{
+			"too small",
+			256,
+			false,
+			srp.ErrGroupTooSmall,
+		},
+		{
+			"valid",
+			1024,
+			true,
+			nil,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			if table.slow && testing.Short() {
+				t.Skip("skipping in short mode")
+			}
+
+			var tested uint64
+
+			// A fixed seed and a single worker always find the same
+			// group so the test takes the same time on each run
+			r := rand.New(rand.NewSource(1)) //nolint:gosec
+
+			g, err := srp.GenerateGroup(context.Background(), table.bits, r, srp.GenerateWorkers(1),
+				srp.GenerateProgress(func(n uint64) {
+					atomic.StoreUint64(&tested, n)
+				}))
+			require.NoError(t, err)
+
+			assert.Equal(t, table.bits, g.N.BitLen())
+			assert.NotZero(t, atomic.LoadUint64(&tested))
+			assert.ErrorIs(t, g.Validate(), table.err)
+
+			n, err := srp.NewGroup(g.G.Int64(), table.bits, g.Hex())
+			require.NoError(t, err)
+
+			assert.Equal(t, g, n)
+		})
+	}
+}
+
+func TestGenerateGroupErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := srp.GenerateGroup(ctx, 4096, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Options can be built up outside of the package
+	options := []srp.GenerateOption{srp.GenerateWorkers(0)}
+
+	_, err = srp.GenerateGroup(context.Background(), 1024, nil, options...)
+	assert.Error(t, err)
+
+	_, err = srp.GenerateGroup(context.Background(), 32, nil)
+	assert.Error(t, err)
+
+	// NewGroup can only read back a whole number of bytes
+	_, err = srp.GenerateGroup(context.Background(), 260, nil)
+	assert.Error(t, err)
+}
+
+func TestGroupHex(t *testing.T) {
+	t.Parallel()
+
+	g := util.Must(srp.GetGroup(1024))
+
+	assert.Equal(t, rfc5054.Hex1024, g.Hex())
+}
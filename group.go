@@ -1,10 +1,13 @@
 package srp
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
+	"github.com/bodgit/srp/internal/rfc3526"
 	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/rfc7919"
 	"github.com/bodgit/srp/internal/util"
 )
 
@@ -15,6 +18,32 @@ type Group struct {
 	Size int
 }
 
+// MinGroupBits is the minimum size of N in bits accepted by Validate.
+const MinGroupBits = 1024
+
+// primeRounds is the number of Miller-Rabin rounds used by Validate in
+// addition to the Baillie-PSW test.
+const primeRounds = 20
+
+var (
+	// ErrGroupSizeMismatch means the Size of a Group does not match the
+	// length of N.
+	ErrGroupSizeMismatch = errors.New("size does not match N")
+
+	// ErrGroupTooSmall means N is smaller than MinGroupBits.
+	ErrGroupTooSmall = fmt.Errorf("N is smaller than %d bits", MinGroupBits)
+
+	// ErrGroupNotPrime means N is not prime.
+	ErrGroupNotPrime = errors.New("N is not prime")
+
+	// ErrGroupNotSafePrime means N is prime but (N-1)/2 is not, so N is
+	// not a safe prime.
+	ErrGroupNotSafePrime = errors.New("N is not a safe prime")
+
+	// ErrInvalidGenerator means g does not generate a large subgroup.
+	ErrInvalidGenerator = errors.New("g does not generate a large subgroup")
+)
+
 //nolint:gochecknoglobals
 var rfcGroups = map[int]*Group{
 	1024: util.Must(NewGroup(2, 1024, rfc5054.Hex1024)),
@@ -26,6 +55,45 @@ var rfcGroups = map[int]*Group{
 	8192: util.Must(NewGroup(19, 8192, rfc5054.Hex8192)),
 }
 
+// wellKnownGroups is indexed by the identifier used to record a group in an
+// ISV, the zero identifier means the group parameters are recorded in full.
+//
+//nolint:gochecknoglobals
+var wellKnownGroups = []*Group{
+	nil,
+	rfcGroups[1024],
+	rfcGroups[1536],
+	rfcGroups[2048],
+	rfcGroups[3072],
+	rfcGroups[4096],
+	rfcGroups[6144],
+	rfcGroups[8192],
+}
+
+// namedGroups holds the standard groups by name, see GetGroupByName.
+//
+//nolint:gochecknoglobals
+var namedGroups = map[string]*Group{
+	"rfc5054-1024": rfcGroups[1024],
+	"rfc5054-1536": rfcGroups[1536],
+	"rfc5054-2048": rfcGroups[2048],
+	"rfc5054-3072": rfcGroups[3072],
+	"rfc5054-4096": rfcGroups[4096],
+	"rfc5054-6144": rfcGroups[6144],
+	"rfc5054-8192": rfcGroups[8192],
+	"ffdhe2048":    util.Must(NewGroup(2, 2048, rfc7919.Hex2048)),
+	"ffdhe3072":    util.Must(NewGroup(2, 3072, rfc7919.Hex3072)),
+	"ffdhe4096":    util.Must(NewGroup(2, 4096, rfc7919.Hex4096)),
+	"ffdhe6144":    util.Must(NewGroup(2, 6144, rfc7919.Hex6144)),
+	"ffdhe8192":    util.Must(NewGroup(2, 8192, rfc7919.Hex8192)),
+	"modp1536":     util.Must(NewGroup(2, 1536, rfc3526.Hex1536)),
+	"modp2048":     util.Must(NewGroup(2, 2048, rfc3526.Hex2048)),
+	"modp3072":     util.Must(NewGroup(2, 3072, rfc3526.Hex3072)),
+	"modp4096":     util.Must(NewGroup(2, 4096, rfc3526.Hex4096)),
+	"modp6144":     util.Must(NewGroup(2, 6144, rfc3526.Hex6144)),
+	"modp8192":     util.Must(NewGroup(2, 8192, rfc3526.Hex8192)),
+}
+
 // NewGroup returns a Group with the generator g, and a prime of size bits set
 // to the bytes decoded from s.
 func NewGroup(g int64, size int, s string) (*Group, error) {
@@ -43,6 +111,20 @@ func NewGroup(g int64, size int, s string) (*Group, error) {
 	return group, nil
 }
 
+// NewStrictGroup is like NewGroup but also checks the Group with Validate.
+func NewStrictGroup(g int64, size int, s string) (*Group, error) {
+	group, err := NewGroup(g, size, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
 // GetGroup returns the RFC 5054 group for the prime of n bits.
 func GetGroup(n int) (*Group, error) {
 	group, ok := rfcGroups[n]
@@ -52,3 +134,90 @@ func GetGroup(n int) (*Group, error) {
 
 	return group, nil
 }
+
+// Validate checks that Size matches the length of N, that N is at least
+// MinGroupBits and is a safe prime, and that g generates a subgroup of order
+// at least (N-1)/2. The primality tests are probabilistic and can be slow for
+// large groups, the standard groups returned by GetGroupByName are
+// recognised and not tested again.
+func (g *Group) Validate() error {
+	if g.N == nil || g.N.Sign() <= 0 {
+		return ErrGroupNotPrime
+	}
+
+	if size := (g.N.BitLen() + 7) >> 3; g.Size != size {
+		return fmt.Errorf("%w: N is %d bytes but size is %d", ErrGroupSizeMismatch, size, g.Size)
+	}
+
+	if bits := g.N.BitLen(); bits < MinGroupBits {
+		return fmt.Errorf("%w: N is %d bits", ErrGroupTooSmall, bits)
+	}
+
+	// Every element other than 1 and N-1 generates a subgroup of order q or
+	// 2q when N = 2q + 1
+	if g.G == nil || g.G.Cmp(big.NewInt(1)) <= 0 || g.G.Cmp(new(big.Int).Sub(g.N, big.NewInt(1))) >= 0 {
+		return ErrInvalidGenerator
+	}
+
+	if _, ok := LookupGroup(g.N, g.G); ok {
+		return nil
+	}
+
+	if !g.N.ProbablyPrime(primeRounds) {
+		return ErrGroupNotPrime
+	}
+
+	if !new(big.Int).Rsh(g.N, 1).ProbablyPrime(primeRounds) {
+		return ErrGroupNotSafePrime
+	}
+
+	return nil
+}
+
+// GetGroupByName returns the standard group with the name. The RFC 5054
+// groups are named rfc5054-1024 to rfc5054-8192, the RFC 7919 groups are
+// named ffdhe2048 to ffdhe8192 and the RFC 3526 MODP groups are named
+// modp1536 to modp8192. The RFC 3526 groups from 3072 bits use the same
+// primes as RFC 5054 but with a generator of 2.
+func GetGroupByName(name string) (*Group, error) {
+	group, ok := namedGroups[name]
+	if !ok {
+		return nil, util.ErrGroupNotFound
+	}
+
+	return group, nil
+}
+
+// LookupGroup returns the name of the standard group with the prime n and the
+// generator g, if there is one.
+func LookupGroup(n, g *big.Int) (string, bool) {
+	for name, group := range namedGroups {
+		if group.N.Cmp(n) == 0 && group.G.Cmp(g) == 0 {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+func (g *Group) equal(o *Group) bool {
+	return g.G.Cmp(o.G) == 0 && g.N.Cmp(o.N) == 0 && g.Size == o.Size
+}
+
+func groupID(g *Group) uint8 {
+	for i, group := range wellKnownGroups[1:] {
+		if group.equal(g) {
+			return uint8(i + 1) //nolint:gosec
+		}
+	}
+
+	return 0
+}
+
+func groupByID(id uint8) (*Group, error) {
+	if id == 0 || int(id) >= len(wellKnownGroups) {
+		return nil, util.ErrGroupNotFound
+	}
+
+	return wellKnownGroups[id], nil
+}
@@ -2,12 +2,14 @@ package srp_test
 
 import (
 	"encoding/hex"
+	"math/big"
 	"testing"
 
 	"github.com/bodgit/srp"
 	"github.com/bodgit/srp/internal/rfc5054"
 	"github.com/bodgit/srp/internal/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewGroup(t *testing.T) {
@@ -89,3 +91,267 @@ func TestGetGroup(t *testing.T) {
 		}
 	}
 }
+
+const (
+	safePrime = `
+		C75B89AB F246C4C8 FD4CBF2A 46CD4824 2E7BF1FD 2377321D B7A73069
+		E4E0FC78 52B2BE05 DF79124A 14CA6195 53A0390C 98544DA2 C65AAD9A
+		542751C6 3E7882E8 61BF2197 7290D256 1E3856D5 281EC68A 7DA75DE7
+		082FCE38 47A05DF4 41F93555 4280E5FD BE66F79E 19FBD46B B274C3D2
+		855964E8 C909237D EFEBFC3F 50F31C93`
+	unsafePrime = `
+		C751DBD6 E3F9E537 3B05BAAD EE4BE2AE EA57E81E 948C4DDF 9FD865CE
+		F44A939C F365F701 7E4DDD87 2869354F 87422406 E615C21A F20779E0
+		981B7049 AEE9DD6C 7999324C FD9C5AE0 1744A683 ED4361D2 080805BC
+		B76B725F 7843FBF4 BCF9C27C 9ED8B368 764D61F7 72FA59EB 28CEEC66
+		E2ED60C5 B306F437 CBA1125E 504C7457`
+	composite = `
+		C75B89AB F246C4C8 FD4CBF2A 46CD4824 2E7BF1FD 2377321D B7A73069
+		E4E0FC78 52B2BE05 DF79124A 14CA6195 53A0390C 98544DA2 C65AAD9A
+		542751C6 3E7882E8 61BF2197 7290D256 1E3856D5 281EC68A 7DA75DE7
+		082FCE38 47A05DF4 41F93555 4280E5FD BE66F79E 19FBD46B B274C3D2
+		855964E8 C909237D EFEBFC3F 50F31C95`
+)
+
+func TestGroupValidate(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		name string
+		g    int64
+		size int
+		s    string
+		err  error
+	}{
+		{
+			"rfc5054",
+			2,
+			2048,
+			rfc5054.Hex2048,
+			nil,
+		},
+		{
+			"safe prime",
+			2,
+			1024,
+			safePrime,
+			nil,
+		},
+		{
+			"size mismatch",
+			2,
+			2048,
+			safePrime,
+			srp.ErrGroupSizeMismatch,
+		},
+		{
+			"too small",
+			7,
+			256,
+			"894B645E 89E1535B BDAD5B8B 29065053 0801B18E BFBF5E8F AB3C8287 2A3E9BB7",
+			srp.ErrGroupTooSmall,
+		},
+		{
+			"generator too small",
+			1,
+			1024,
+			safePrime,
+			srp.ErrInvalidGenerator,
+		},
+		{
+			"not prime",
+			2,
+			1024,
+			composite,
+			srp.ErrGroupNotPrime,
+		},
+		{
+			"not safe prime",
+			2,
+			1024,
+			unsafePrime,
+			srp.ErrGroupNotSafePrime,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := srp.NewStrictGroup(table.g, table.size, table.s)
+			assert.ErrorIs(t, err, table.err)
+
+			// NewGroup accepts the same group without checking it
+			g, err := srp.NewGroup(table.g, table.size, table.s)
+			require.NoError(t, err)
+
+			assert.ErrorIs(t, g.Validate(), table.err)
+		})
+	}
+
+	g := util.Must(srp.NewGroup(2, 1024, safePrime))
+	g.G = new(big.Int).Sub(g.N, big.NewInt(1))
+
+	assert.ErrorIs(t, g.Validate(), srp.ErrInvalidGenerator)
+}
+
+func fermat(n *big.Int) bool {
+	one := big.NewInt(1)
+
+	return new(big.Int).Exp(big.NewInt(3), new(big.Int).Sub(n, one), n).Cmp(one) == 0
+}
+
+func TestGetGroupByName(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		name string
+		bits int
+		err  error
+	}{
+		{
+			"rfc5054-1024",
+			1024,
+			nil,
+		},
+		{
+			"rfc5054-1536",
+			1536,
+			nil,
+		},
+		{
+			"rfc5054-2048",
+			2048,
+			nil,
+		},
+		{
+			"rfc5054-3072",
+			3072,
+			nil,
+		},
+		{
+			"rfc5054-4096",
+			4096,
+			nil,
+		},
+		{
+			"rfc5054-6144",
+			6144,
+			nil,
+		},
+		{
+			"rfc5054-8192",
+			8192,
+			nil,
+		},
+		{
+			"ffdhe2048",
+			2048,
+			nil,
+		},
+		{
+			"ffdhe3072",
+			3072,
+			nil,
+		},
+		{
+			"ffdhe4096",
+			4096,
+			nil,
+		},
+		{
+			"ffdhe6144",
+			6144,
+			nil,
+		},
+		{
+			"ffdhe8192",
+			8192,
+			nil,
+		},
+		{
+			"modp1536",
+			1536,
+			nil,
+		},
+		{
+			"modp2048",
+			2048,
+			nil,
+		},
+		{
+			"modp3072",
+			3072,
+			nil,
+		},
+		{
+			"modp4096",
+			4096,
+			nil,
+		},
+		{
+			"modp6144",
+			6144,
+			nil,
+		},
+		{
+			"modp8192",
+			8192,
+			nil,
+		},
+		{
+			"modp1024",
+			0,
+			util.ErrGroupNotFound,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			g, err := srp.GetGroupByName(table.name)
+			if table.err != nil {
+				assert.ErrorIs(t, err, table.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, table.bits, g.N.BitLen())
+			assert.Equal(t, table.bits>>3, g.Size)
+
+			// Validate skips the primality tests for the standard groups
+			// so check N and (N-1)/2 with a quick Fermat test instead
+			assert.True(t, fermat(g.N))
+			assert.True(t, fermat(new(big.Int).Rsh(g.N, 1)))
+
+			name, ok := srp.LookupGroup(g.N, g.G)
+			assert.True(t, ok)
+			assert.Equal(t, table.name, name)
+		})
+	}
+}
+
+func TestLookupGroup(t *testing.T) {
+	t.Parallel()
+
+	g := util.Must(srp.GetGroup(3072))
+
+	name, ok := srp.LookupGroup(g.N, g.G)
+	assert.True(t, ok)
+	assert.Equal(t, "rfc5054-3072", name)
+
+	// The same prime with a generator of 2 is the RFC 3526 group
+	name, ok = srp.LookupGroup(g.N, big.NewInt(2))
+	assert.True(t, ok)
+	assert.Equal(t, "modp3072", name)
+
+	_, ok = srp.LookupGroup(g.N, big.NewInt(3))
+	assert.False(t, ok)
+}
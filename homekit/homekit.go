@@ -0,0 +1,145 @@
+// Package homekit implements the SRP profile used by Apple HomeKit
+// Accessory Protocol (HAP) pair-setup along with the TLV8 message encoding and
+// the M1 to M4 exchange for both the controller and accessory.
+//
+// HAP pads A, B and the premaster secret to the size of the group when
+// computing the proofs and the session key, and when sending A and B, as
+// the HomeKit Accessory Development Kit does, rather than using the minimal
+// encoding of RFC 5054.
+//
+// Unsuccessful attempts are counted with Attempts, which should be shared
+// between each accessory, so pair-setup is refused after MaxTries attempts.
+package homekit
+
+import (
+	"crypto"
+	_ "crypto/sha512" // HAP uses SHA-512
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/util"
+)
+
+// Identity is the SRP identity used for pair-setup.
+const Identity = "Pair-Setup"
+
+const saltSize = 16
+
+// Error is the value of a TLV8 item of TypeError.
+type Error uint8
+
+// Errors that can be returned by an accessory.
+const (
+	ErrorUnknown        Error = 0x01
+	ErrorAuthentication Error = 0x02
+	ErrorBackoff        Error = 0x03
+	ErrorMaxPeers       Error = 0x04
+	ErrorMaxTries       Error = 0x05
+	ErrorUnavailable    Error = 0x06
+	ErrorBusy           Error = 0x07
+)
+
+func (e Error) Error() string {
+	switch e {
+	case ErrorUnknown:
+		return "unknown error"
+	case ErrorAuthentication:
+		return "authentication failed"
+	case ErrorBackoff:
+		return "too many attempts, retry later"
+	case ErrorMaxPeers:
+		return "no more pairings can be added"
+	case ErrorMaxTries:
+		return "too many authentication attempts"
+	case ErrorUnavailable:
+		return "already paired"
+	case ErrorBusy:
+		return "busy pairing with another controller"
+	default:
+		return fmt.Sprintf("error %#02x", uint8(e))
+	}
+}
+
+var (
+	// ErrInvalidSetupCode means the setup code is not of the form
+	// XXX-XX-XXX.
+	ErrInvalidSetupCode = errors.New("invalid setup code")
+
+	// ErrUnexpectedState means a message was received out of order.
+	ErrUnexpectedState = errors.New("unexpected state")
+
+	// ErrMissingItem means a message was missing a required item.
+	ErrMissingItem = errors.New("missing TLV8 item")
+)
+
+// NewSRP returns a new srp.SRP struct using the 3072-bit group, SHA-512 and
+// 16-byte salts, with A, B and the premaster secret padded to the size of the
+// group, along with any additional options.
+func NewSRP(options ...func(*srp.SRP) error) (*srp.SRP, error) {
+	//nolint:wrapcheck
+	return srp.NewSRP(crypto.SHA512, util.Must(srp.GetGroup(3072)), append([]func(*srp.SRP) error{
+		srp.SaltSize(saltSize),
+		srp.SessionKey(sessionKey),
+		srp.M1(m1),
+		srp.M2(m2),
+	}, options...)...)
+}
+
+// pad returns x padded to the size of the group.
+func pad(s *srp.SRP, x *big.Int) []byte {
+	return util.Pad(x, s.Group().Size)
+}
+
+// sessionKey computes K = H(PAD(S)).
+func sessionKey(s *srp.SRP, xS *big.Int) []byte {
+	return s.HashBytes(pad(s, xS))
+}
+
+// m1 computes M1 = H(H(N) XOR H(g) | H(U) | s | PAD(A) | PAD(B) | K).
+func m1(s *srp.SRP, xA, xB, _ *big.Int, xK, identity, salt []byte) []byte {
+	xor := s.HashBytes(s.Group().N.Bytes())
+	for i, b := range s.HashBytes(s.Group().G.Bytes()) {
+		xor[i] ^= b
+	}
+
+	return s.HashBytes(xor, s.HashBytes(identity), salt, pad(s, xA), pad(s, xB), xK)
+}
+
+// m2 computes M2 = H(PAD(A) | M1 | K).
+func m2(s *srp.SRP, xA, _ *big.Int, m1, xK []byte) []byte {
+	return s.HashBytes(pad(s, xA), m1, xK)
+}
+
+// NewISV creates a new ISV for the setup code using s, which should be
+// created with NewSRP.
+func NewISV(s *srp.SRP, code string) (*srp.ISV, error) {
+	if !validSetupCode(code) {
+		return nil, ErrInvalidSetupCode
+	}
+
+	//nolint:wrapcheck
+	return s.NewISV([]byte(Identity), []byte(code))
+}
+
+func validSetupCode(code string) bool {
+	if len(code) != len("XXX-XX-XXX") {
+		return false
+	}
+
+	for i, c := range code {
+		switch i {
+		case 3, 6: //nolint:gomnd
+			if c != '-' {
+				return false
+			}
+		default:
+			if c < '0' || c > '9' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
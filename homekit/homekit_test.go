@@ -0,0 +1,132 @@
+package homekit_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/homekit"
+	vectors "github.com/bodgit/srp/internal/homekit"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	s, err := homekit.NewSRP(srp.Rand(bytes.NewReader(rfc5054.Salt)))
+	require.NoError(t, err)
+
+	i, err := s.NewISV(rfc5054.Identity, rfc5054.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, rfc5054.Salt, i.Salt)
+	assert.Equal(t, vectors.V, i.Verifier)
+
+	require.NoError(t, s.SetRand(util.FixedRand(rfc5054.B, 384)))
+
+	server, err := s.NewDeferredServer(i)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.XB, server.B())
+
+	require.NoError(t, s.SetRand(util.FixedRand(rfc5054.A, 384)))
+
+	client, err := s.NewClient(rfc5054.Identity, rfc5054.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.XA, client.A())
+
+	m1, err := client.Compute(server.Salt(), server.B())
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.U, util.Must(client.U()))
+	assert.Equal(t, vectors.PremasterSecret, util.Must(client.S()))
+	assert.Equal(t, vectors.K, client.Key())
+	assert.Equal(t, vectors.M1, m1)
+
+	m2, err := server.Check(client.A(), m1)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.M2, m2)
+	assert.NoError(t, client.Check(m2))
+}
+
+func TestPaddedVectors(t *testing.T) {
+	t.Parallel()
+
+	s, err := homekit.NewSRP(srp.Rand(bytes.NewReader(rfc5054.Salt)))
+	require.NoError(t, err)
+
+	i, err := s.NewISV(rfc5054.Identity, rfc5054.Password)
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetRand(util.FixedRand(vectors.PaddedB, 384)))
+
+	server, err := s.NewDeferredServer(i)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.PaddedXB, util.Pad(s.Decode(server.B()), 384))
+
+	require.NoError(t, s.SetRand(util.FixedRand(vectors.PaddedA, 384)))
+
+	client, err := s.NewClient(rfc5054.Identity, rfc5054.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.PaddedXA, util.Pad(s.Decode(client.A()), 384))
+
+	m1, err := client.Compute(server.Salt(), vectors.PaddedXB)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.PaddedK, client.Key())
+	assert.Equal(t, vectors.PaddedM1, m1)
+
+	m2, err := server.Check(vectors.PaddedXA, m1)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.PaddedM2, m2)
+	assert.NoError(t, client.Check(m2))
+}
+
+func TestNewISV(t *testing.T) {
+	t.Parallel()
+
+	s := util.Must(homekit.NewSRP())
+
+	tables := []struct {
+		code string
+		err  error
+	}{
+		{
+			"031-45-154",
+			nil,
+		},
+		{
+			"03145154",
+			homekit.ErrInvalidSetupCode,
+		},
+		{
+			"031-45-15a",
+			homekit.ErrInvalidSetupCode,
+		},
+		{
+			"031+45-154",
+			homekit.ErrInvalidSetupCode,
+		},
+	}
+
+	for _, table := range tables {
+		i, err := homekit.NewISV(s, table.code)
+		if table.err != nil {
+			assert.ErrorIs(t, err, table.err)
+
+			continue
+		}
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte(homekit.Identity), i.Identity)
+		assert.Len(t, i.Salt, 16)
+	}
+}
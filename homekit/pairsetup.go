@@ -0,0 +1,352 @@
+package homekit
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bodgit/srp"
+)
+
+// Pair-setup states, sent as the value of a TLV8 item of TypeState.
+const (
+	StateM1 uint8 = iota + 1
+	StateM2
+	StateM3
+	StateM4
+)
+
+// stateFailed is the terminal state of an Accessory after the controller
+// proof is incorrect.
+const stateFailed uint8 = 0xff
+
+// MethodPairSetup is the value of the TypeMethod item in M1.
+const MethodPairSetup uint8 = 0x00
+
+// MaxTries is the number of unsuccessful pair-setup attempts after which an
+// accessory refuses pair-setup with ErrorMaxTries.
+const MaxTries = 100
+
+// Attempts counts the unsuccessful pair-setup attempts of an accessory. The
+// same Attempts should be shared by each Accessory and the count persisted so
+// it survives restarts. It is safe for concurrent use.
+//
+// Each attempt is counted when it starts and only uncounted if it succeeds,
+// so attempts in progress on several Accessory at once cannot exceed
+// MaxTries and an attempt that is never finished counts as unsuccessful.
+type Attempts struct {
+	mu sync.Mutex
+	n  int
+}
+
+// NewAttempts returns a new Attempts starting from the count n, such as a
+// previously persisted count.
+func NewAttempts(n int) *Attempts {
+	return &Attempts{n: n}
+}
+
+// Count returns the number of unsuccessful attempts.
+func (a *Attempts) Count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.n
+}
+
+// reserve counts a new attempt unless there have already been MaxTries, in
+// which case it returns false.
+func (a *Attempts) reserve() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.n >= MaxTries {
+		return false
+	}
+
+	a.n++
+
+	return true
+}
+
+// release uncounts an attempt that succeeded.
+func (a *Attempts) release() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.n--
+}
+
+// Controller performs the controller side of pair-setup.
+type Controller struct {
+	srp    *srp.SRP
+	code   string
+	client *srp.Client
+	state  uint8
+}
+
+// NewController returns a new Controller using the setup code of the
+// accessory. Any options are passed to NewSRP.
+func NewController(code string, options ...func(*srp.SRP) error) (*Controller, error) {
+	if !validSetupCode(code) {
+		return nil, ErrInvalidSetupCode
+	}
+
+	s, err := NewSRP(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Controller{
+		srp:  s,
+		code: code,
+	}, nil
+}
+
+// M1 returns the M1 request which starts pair-setup.
+func (c *Controller) M1() ([]byte, error) {
+	if c.state != 0 {
+		return nil, ErrUnexpectedState
+	}
+
+	c.state = StateM1
+
+	return TLV8{
+		{TypeState, []byte{StateM1}},
+		{TypeMethod, []byte{MethodPairSetup}},
+	}.MarshalBinary()
+}
+
+// M3 takes the M2 response from the accessory and returns the M3 request
+// containing the controller public value and proof. If the accessory
+// responded with an error then it is returned as an Error.
+func (c *Controller) M3(m2 []byte) ([]byte, error) {
+	if c.state != StateM1 {
+		return nil, ErrUnexpectedState
+	}
+
+	t, err := parse(m2, StateM2)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := items(t, TypeSalt, TypePublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.client, err = c.srp.NewClient([]byte(Identity), []byte(c.code)); err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	m1, err := c.client.Compute(v[0], v[1])
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute proof: %w", err)
+	}
+
+	c.state = StateM3
+
+	return TLV8{
+		{TypeState, []byte{StateM3}},
+		{TypePublicKey, pad(c.srp, c.srp.Decode(c.client.A()))},
+		{TypeProof, m1},
+	}.MarshalBinary()
+}
+
+// Finish takes the M4 response from the accessory and checks its proof. If
+// the accessory responded with an error then it is returned as an Error.
+func (c *Controller) Finish(m4 []byte) error {
+	if c.state != StateM3 {
+		return ErrUnexpectedState
+	}
+
+	t, err := parse(m4, StateM4)
+	if err != nil {
+		return err
+	}
+
+	m2, err := items(t, TypeProof)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.Check(m2[0]); err != nil {
+		return fmt.Errorf("unable to check proof: %w", err)
+	}
+
+	c.state = StateM4
+
+	return nil
+}
+
+// Key returns the SRP session key after Finish has succeeded, which is used
+// to derive the keys for the M5 and M6 exchange.
+func (c *Controller) Key() ([]byte, error) {
+	if c.state != StateM4 {
+		return nil, ErrUnexpectedState
+	}
+
+	return c.client.Key(), nil
+}
+
+// Accessory performs the accessory side of pair-setup. The accessory sends
+// its salt and public value before receiving the controller public value so
+// it uses srp.DeferredServer. Each Accessory handles a single attempt, if the
+// controller proof is incorrect then any further messages are rejected.
+type Accessory struct {
+	srp      *srp.SRP
+	isv      *srp.ISV
+	server   *srp.DeferredServer
+	attempts *Attempts
+	state    uint8
+}
+
+// NewAccessory returns a new Accessory using the ISV, which should be
+// created with NewISV. Any options are passed to NewSRP.
+func NewAccessory(i *srp.ISV, options ...func(*srp.SRP) error) (*Accessory, error) {
+	s, err := NewSRP(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Accessory{
+		srp: s,
+		isv: i,
+	}, nil
+}
+
+// SetAttempts sets the count of unsuccessful attempts which is incremented
+// when M1 is received and decremented again if the controller proof is
+// correct. Once it reaches MaxTries the accessory refuses pair-setup.
+func (a *Accessory) SetAttempts(attempts *Attempts) {
+	a.attempts = attempts
+}
+
+// M2 takes the M1 request from the controller and returns the M2 response
+// containing the salt and the accessory public value. If there have been
+// MaxTries unsuccessful or unfinished attempts then the returned response
+// contains ErrorMaxTries which is also returned as the error, the response
+// should still be sent to the controller.
+func (a *Accessory) M2(m1 []byte) ([]byte, error) {
+	if a.state != 0 {
+		return nil, ErrUnexpectedState
+	}
+
+	if a.attempts != nil && !a.attempts.reserve() {
+		a.state = stateFailed
+
+		b, _ := TLV8{
+			{TypeState, []byte{StateM2}},
+			{TypeError, []byte{byte(ErrorMaxTries)}},
+		}.MarshalBinary()
+
+		return b, ErrorMaxTries
+	}
+
+	t, err := parse(m1, StateM1)
+	if err != nil {
+		return nil, err
+	}
+
+	if method, ok := t.Byte(TypeMethod); !ok || method != MethodPairSetup {
+		return nil, fmt.Errorf("%w: method", ErrMissingItem)
+	}
+
+	if a.server, err = a.srp.NewDeferredServer(a.isv); err != nil {
+		return nil, fmt.Errorf("unable to create server: %w", err)
+	}
+
+	a.state = StateM2
+
+	return TLV8{
+		{TypeState, []byte{StateM2}},
+		{TypePublicKey, pad(a.srp, a.srp.Decode(a.server.B()))},
+		{TypeSalt, a.server.Salt()},
+	}.MarshalBinary()
+}
+
+// M4 takes the M3 request from the controller and returns the M4 response
+// containing the accessory proof. If the controller proof is incorrect then
+// the returned response contains ErrorAuthentication which is also returned
+// as the error, the response should still be sent to the controller. The
+// attempt has then failed and any further messages return
+// ErrUnexpectedState.
+func (a *Accessory) M4(m3 []byte) ([]byte, error) {
+	if a.state != StateM2 {
+		return nil, ErrUnexpectedState
+	}
+
+	t, err := parse(m3, StateM3)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := items(t, TypePublicKey, TypeProof)
+	if err != nil {
+		return nil, err
+	}
+
+	m2, err := a.server.Check(v[0], v[1])
+	if err != nil {
+		a.state = stateFailed
+
+		b, _ := TLV8{
+			{TypeState, []byte{StateM4}},
+			{TypeError, []byte{byte(ErrorAuthentication)}},
+		}.MarshalBinary()
+
+		return b, ErrorAuthentication
+	}
+
+	a.state = StateM4
+
+	if a.attempts != nil {
+		a.attempts.release()
+	}
+
+	return TLV8{
+		{TypeState, []byte{StateM4}},
+		{TypeProof, m2},
+	}.MarshalBinary()
+}
+
+// Key returns the SRP session key after M4 has succeeded, which is used to
+// derive the keys for the M5 and M6 exchange.
+func (a *Accessory) Key() ([]byte, error) {
+	if a.state != StateM4 {
+		return nil, ErrUnexpectedState
+	}
+
+	//nolint:wrapcheck
+	return a.server.Key()
+}
+
+func parse(b []byte, state uint8) (TLV8, error) {
+	var t TLV8
+	if err := t.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	if s, ok := t.Byte(TypeState); !ok || s != state {
+		return nil, ErrUnexpectedState
+	}
+
+	if e, ok := t.Byte(TypeError); ok {
+		return nil, Error(e)
+	}
+
+	return t, nil
+}
+
+func items(t TLV8, types ...uint8) ([][]byte, error) {
+	values := make([][]byte, 0, len(types))
+
+	for _, typ := range types {
+		v, ok := t.Get(typ)
+		if !ok {
+			return nil, fmt.Errorf("%w: %#02x", ErrMissingItem, typ)
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
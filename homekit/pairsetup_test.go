@@ -0,0 +1,236 @@
+package homekit_test
+
+import (
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/homekit"
+	vectors "github.com/bodgit/srp/internal/homekit"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const code = "031-45-154"
+
+//nolint:funlen
+func TestPairSetup(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		name string
+		code string
+		err  error
+	}{
+		{
+			"success",
+			code,
+			nil,
+		},
+		{
+			"incorrect setup code",
+			"111-22-333",
+			homekit.ErrorAuthentication,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			i, err := homekit.NewISV(util.Must(homekit.NewSRP()), code)
+			require.NoError(t, err)
+
+			accessory, err := homekit.NewAccessory(i)
+			require.NoError(t, err)
+
+			controller, err := homekit.NewController(table.code)
+			require.NoError(t, err)
+
+			m1, err := controller.M1()
+			require.NoError(t, err)
+
+			m2, err := accessory.M2(m1)
+			require.NoError(t, err)
+
+			m3, err := controller.M3(m2)
+			require.NoError(t, err)
+
+			m4, err := accessory.M4(m3)
+			if table.err != nil {
+				assert.ErrorIs(t, err, table.err)
+				assert.ErrorIs(t, controller.Finish(m4), table.err)
+
+				_, err = accessory.Key()
+				assert.ErrorIs(t, err, homekit.ErrUnexpectedState)
+
+				// The attempt has failed so the proof cannot be retried
+				_, err = accessory.M4(m3)
+				assert.ErrorIs(t, err, homekit.ErrUnexpectedState)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, controller.Finish(m4))
+
+			key, err := accessory.Key()
+			require.NoError(t, err)
+
+			assert.Equal(t, key, util.Must(controller.Key()))
+		})
+	}
+}
+
+func TestPairSetupMaxTries(t *testing.T) {
+	t.Parallel()
+
+	i, err := homekit.NewISV(util.Must(homekit.NewSRP()), code)
+	require.NoError(t, err)
+
+	attempts := homekit.NewAttempts(homekit.MaxTries - 1)
+
+	pairSetup := func(code string) ([]byte, error) {
+		accessory := util.Must(homekit.NewAccessory(i))
+		accessory.SetAttempts(attempts)
+
+		controller := util.Must(homekit.NewController(code))
+
+		m2, err := accessory.M2(util.Must(controller.M1()))
+		if err != nil {
+			return m2, err
+		}
+
+		return accessory.M4(util.Must(controller.M3(m2)))
+	}
+
+	_, err = pairSetup("111-22-333")
+	require.ErrorIs(t, err, homekit.ErrorAuthentication)
+	assert.Equal(t, homekit.MaxTries, attempts.Count())
+
+	// Even the correct setup code is now refused
+	_, err = pairSetup(code)
+	require.ErrorIs(t, err, homekit.ErrorMaxTries)
+
+	accessory := util.Must(homekit.NewAccessory(i))
+	accessory.SetAttempts(attempts)
+
+	controller := util.Must(homekit.NewController(code))
+
+	m2, err := accessory.M2(util.Must(controller.M1()))
+	require.ErrorIs(t, err, homekit.ErrorMaxTries)
+
+	_, err = controller.M3(m2)
+	assert.ErrorIs(t, err, homekit.ErrorMaxTries)
+}
+
+func TestPairSetupAttempts(t *testing.T) {
+	t.Parallel()
+
+	i, err := homekit.NewISV(util.Must(homekit.NewSRP()), code)
+	require.NoError(t, err)
+
+	attempts := homekit.NewAttempts(homekit.MaxTries - 1)
+
+	accessory := util.Must(homekit.NewAccessory(i))
+	accessory.SetAttempts(attempts)
+
+	controller := util.Must(homekit.NewController(code))
+
+	m2, err := accessory.M2(util.Must(controller.M1()))
+	require.NoError(t, err)
+
+	// The attempt in progress counts towards MaxTries
+	assert.Equal(t, homekit.MaxTries, attempts.Count())
+
+	other := util.Must(homekit.NewAccessory(i))
+	other.SetAttempts(attempts)
+
+	_, err = other.M2(util.Must(util.Must(homekit.NewController(code)).M1()))
+	require.ErrorIs(t, err, homekit.ErrorMaxTries)
+
+	// A successful attempt is not counted
+	m4, err := accessory.M4(util.Must(controller.M3(m2)))
+	require.NoError(t, err)
+	require.NoError(t, controller.Finish(m4))
+
+	assert.Equal(t, homekit.MaxTries-1, attempts.Count())
+}
+
+func TestPairSetupPadded(t *testing.T) {
+	t.Parallel()
+
+	i, err := homekit.NewISV(util.Must(homekit.NewSRP()), code)
+	require.NoError(t, err)
+
+	accessory := util.Must(homekit.NewAccessory(i))
+	controller := util.Must(homekit.NewController(code, srp.Rand(util.FixedRand(vectors.PaddedA, 384))))
+
+	m2, err := accessory.M2(util.Must(controller.M1()))
+	require.NoError(t, err)
+
+	var t2 homekit.TLV8
+	require.NoError(t, t2.UnmarshalBinary(m2))
+
+	b, ok := t2.Get(homekit.TypePublicKey)
+	require.True(t, ok)
+	assert.Len(t, b, 384)
+
+	m3, err := controller.M3(m2)
+	require.NoError(t, err)
+
+	var t3 homekit.TLV8
+	require.NoError(t, t3.UnmarshalBinary(m3))
+
+	// A has a leading zero byte which is still sent
+	a, ok := t3.Get(homekit.TypePublicKey)
+	require.True(t, ok)
+	assert.Equal(t, vectors.PaddedXA, a)
+
+	m4, err := accessory.M4(m3)
+	require.NoError(t, err)
+	require.NoError(t, controller.Finish(m4))
+}
+
+func TestPairSetupState(t *testing.T) {
+	t.Parallel()
+
+	i, err := homekit.NewISV(util.Must(homekit.NewSRP()), code)
+	require.NoError(t, err)
+
+	accessory, err := homekit.NewAccessory(i)
+	require.NoError(t, err)
+
+	controller, err := homekit.NewController(code)
+	require.NoError(t, err)
+
+	_, err = controller.M3(nil)
+	assert.ErrorIs(t, err, homekit.ErrUnexpectedState)
+
+	_, err = accessory.M4(nil)
+	assert.ErrorIs(t, err, homekit.ErrUnexpectedState)
+
+	m1, err := controller.M1()
+	require.NoError(t, err)
+
+	_, err = controller.M1()
+	assert.ErrorIs(t, err, homekit.ErrUnexpectedState)
+
+	// M1 sent back to the controller has the wrong state
+	_, err = controller.M3(m1)
+	assert.ErrorIs(t, err, homekit.ErrUnexpectedState)
+
+	m2, err := homekit.TLV8{
+		{Type: homekit.TypeState, Value: []byte{homekit.StateM2}},
+		{Type: homekit.TypeError, Value: []byte{byte(homekit.ErrorUnavailable)}},
+	}.MarshalBinary()
+	require.NoError(t, err)
+
+	_, err = controller.M3(m2)
+	assert.ErrorIs(t, err, homekit.ErrorUnavailable)
+
+	_, err = homekit.NewController("1234")
+	assert.ErrorIs(t, err, homekit.ErrInvalidSetupCode)
+}
@@ -0,0 +1,121 @@
+package homekit
+
+import (
+	"bytes"
+	"errors"
+	"math"
+)
+
+// Types of TLV8 items used by pair-setup.
+const (
+	TypeMethod        uint8 = 0x00
+	TypeIdentifier    uint8 = 0x01
+	TypeSalt          uint8 = 0x02
+	TypePublicKey     uint8 = 0x03
+	TypeProof         uint8 = 0x04
+	TypeEncryptedData uint8 = 0x05
+	TypeState         uint8 = 0x06
+	TypeError         uint8 = 0x07
+	TypeRetryDelay    uint8 = 0x08
+	TypeCertificate   uint8 = 0x09
+	TypeSignature     uint8 = 0x0a
+	TypePermissions   uint8 = 0x0b
+	TypeFragmentData  uint8 = 0x0c
+	TypeFragmentLast  uint8 = 0x0d
+	TypeFlags         uint8 = 0x13
+	TypeSeparator     uint8 = 0xff
+)
+
+// ErrTruncated means a TLV8 item is shorter than its length.
+var ErrTruncated = errors.New("truncated TLV8 item")
+
+// Item is a single TLV8 item.
+type Item struct {
+	Type  uint8
+	Value []byte
+}
+
+// TLV8 is a sequence of TLV8 items. Values longer than 255 bytes are
+// fragmented across consecutive items of the same type when marshalled and
+// reassembled when unmarshalled. Two items of the same type must be separated
+// with an item of TypeSeparator.
+type TLV8 []Item
+
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface.
+func (t TLV8) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+
+	for _, item := range t {
+		v := item.Value
+
+		for {
+			n := len(v)
+			if n > math.MaxUint8 {
+				n = math.MaxUint8
+			}
+
+			_ = b.WriteByte(item.Type)
+			_ = b.WriteByte(byte(n))
+			_, _ = b.Write(v[:n])
+
+			// A value that is an exact multiple of 255 bytes has no
+			// trailing empty fragment
+			if v = v[n:]; len(v) == 0 {
+				break
+			}
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalBinary satisfies the encoding.BinaryUnmarshaler interface.
+func (t *TLV8) UnmarshalBinary(b []byte) error {
+	items := TLV8{}
+	fragment := false
+
+	for len(b) > 0 {
+		if len(b) < 2 || len(b) < 2+int(b[1]) {
+			return ErrTruncated
+		}
+
+		typ, n := b[0], int(b[1])
+		v := b[2 : 2+n]
+		b = b[2+n:]
+
+		// Continue the previous item if it was a full fragment
+		if fragment && items[len(items)-1].Type == typ {
+			items[len(items)-1].Value = append(items[len(items)-1].Value, v...)
+		} else {
+			items = append(items, Item{Type: typ, Value: append([]byte{}, v...)})
+		}
+
+		fragment = n == math.MaxUint8
+	}
+
+	*t = items
+
+	return nil
+}
+
+// Get returns the value of the first item of the passed type.
+func (t TLV8) Get(typ uint8) ([]byte, bool) {
+	for _, item := range t {
+		if item.Type == typ {
+			return item.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Byte returns the value of the first item of the passed type which must be
+// exactly one byte long.
+func (t TLV8) Byte(typ uint8) (uint8, bool) {
+	v, ok := t.Get(typ)
+	if !ok || len(v) != 1 {
+		return 0, false
+	}
+
+	return v[0], true
+}
@@ -0,0 +1,120 @@
+package homekit_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bodgit/srp/homekit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestTLV8(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		name string
+		tlv8 homekit.TLV8
+		b    []byte
+	}{
+		{
+			"simple",
+			homekit.TLV8{
+				{Type: homekit.TypeState, Value: []byte{0x03}},
+				{Type: homekit.TypeIdentifier, Value: []byte("hello")},
+			},
+			[]byte{0x06, 0x01, 0x03, 0x01, 0x05, 'h', 'e', 'l', 'l', 'o'},
+		},
+		{
+			"empty",
+			homekit.TLV8{
+				{Type: homekit.TypeState, Value: []byte{0x01}},
+				{Type: homekit.TypeSeparator, Value: []byte{}},
+			},
+			[]byte{0x06, 0x01, 0x01, 0xff, 0x00},
+		},
+		{
+			"fragmented",
+			homekit.TLV8{
+				{Type: homekit.TypeState, Value: []byte{0x03}},
+				{Type: homekit.TypeCertificate, Value: bytes.Repeat([]byte{'a'}, 300)},
+				{Type: homekit.TypeIdentifier, Value: []byte("hello")},
+			},
+			append(append(append(append(
+				[]byte{0x06, 0x01, 0x03, 0x09, 0xff},
+				bytes.Repeat([]byte{'a'}, 255)...),
+				0x09, 0x2d),
+				bytes.Repeat([]byte{'a'}, 45)...),
+				0x01, 0x05, 'h', 'e', 'l', 'l', 'o'),
+		},
+		{
+			"exact fragment",
+			homekit.TLV8{
+				{Type: homekit.TypeCertificate, Value: bytes.Repeat([]byte{'a'}, 255)},
+				{Type: homekit.TypeState, Value: []byte{0x03}},
+			},
+			append(append(
+				[]byte{0x09, 0xff},
+				bytes.Repeat([]byte{'a'}, 255)...),
+				0x06, 0x01, 0x03),
+		},
+		{
+			"separated",
+			homekit.TLV8{
+				{Type: homekit.TypeIdentifier, Value: []byte("a")},
+				{Type: homekit.TypeSeparator, Value: []byte{}},
+				{Type: homekit.TypeIdentifier, Value: []byte("b")},
+			},
+			[]byte{0x01, 0x01, 'a', 0xff, 0x00, 0x01, 0x01, 'b'},
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := table.tlv8.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, table.b, b)
+
+			var tlv8 homekit.TLV8
+			require.NoError(t, tlv8.UnmarshalBinary(b))
+			assert.Equal(t, table.tlv8, tlv8)
+		})
+	}
+}
+
+func TestTLV8Truncated(t *testing.T) {
+	t.Parallel()
+
+	for _, b := range [][]byte{{0x06}, {0x06, 0x02, 0x01}} {
+		var tlv8 homekit.TLV8
+		assert.ErrorIs(t, tlv8.UnmarshalBinary(b), homekit.ErrTruncated)
+	}
+}
+
+func TestTLV8Get(t *testing.T) {
+	t.Parallel()
+
+	tlv8 := homekit.TLV8{
+		{Type: homekit.TypeState, Value: []byte{0x02}},
+		{Type: homekit.TypeSalt, Value: []byte{0x01, 0x02}},
+	}
+
+	v, ok := tlv8.Get(homekit.TypeSalt)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x01, 0x02}, v)
+
+	_, ok = tlv8.Get(homekit.TypeProof)
+	assert.False(t, ok)
+
+	s, ok := tlv8.Byte(homekit.TypeState)
+	assert.True(t, ok)
+	assert.Equal(t, uint8(0x02), s)
+
+	_, ok = tlv8.Byte(homekit.TypeSalt)
+	assert.False(t, ok)
+}
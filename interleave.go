@@ -0,0 +1,31 @@
+package srp
+
+import "math/big"
+
+// Interleave computes the session key K from the premaster secret S using the
+// SHA_Interleave function documented in RFC 2945. It can be used with the
+// SessionKey option. The returned key is twice the size of the hash in use.
+func Interleave(s *SRP, xS *big.Int) []byte {
+	t := s.Encode(xS)
+
+	// Remove a leading byte if there are an odd number of bytes
+	if len(t)&1 == 1 {
+		t = t[1:]
+	}
+
+	e := make([]byte, len(t)/2)
+	f := make([]byte, len(t)/2)
+
+	for i := range e {
+		e[i], f[i] = t[2*i], t[2*i+1]
+	}
+
+	g, h := s.HashBytes(e), s.HashBytes(f)
+
+	k := make([]byte, len(g)+len(h))
+	for i := range g {
+		k[2*i], k[2*i+1] = g[i], h[i]
+	}
+
+	return k
+}
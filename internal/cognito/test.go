@@ -0,0 +1,95 @@
+// Package cognito provides test vectors for the AWS Cognito USER_SRP_AUTH
+// and DEVICE_SRP_AUTH flows. They use the 3072-bit group with SHA-256 and the RFC 5054 Appendix B
+// private values a and b.
+//
+//nolint:gochecknoglobals
+package cognito
+
+import "github.com/bodgit/srp/internal/util"
+
+// USER_SRP_AUTH Test Vectors.
+var (
+	PoolID      = "us-east-2_ABCdef123"
+	Username    = "alice"
+	UserID      = "7f2a8f8e-5f1c-4d0c-9a39-3d3c9b0c2a11"
+	Password    = "password123"
+	Salt        = "b9a2c2d5e1f3a4b5c6d7e8f90a1b2c3d"
+	SecretBlock = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8gISIjJCUmJygpKissLS4vMDEyMzQ1Njc4OTo7PD0+Pw=="
+	Timestamp   = "Tue Jan 2 03:04:05 UTC 2024"
+	A           = util.Must(util.BytesFromHexString(`
+		FA24C7DF 648F8144 BB7F2B7A E8A37796 154B8B72 DE4177EF EADC5D98
+		4901635A 96C11853 3B503249 3D141511 CDFC990E B9886FB6 B4F50609
+		F3CBC614 BADDC7D8 B77DE896 C588E902 D31C1B27 D07B2AFB D8964AAE
+		3F61285D 99D02CE2 4A215F21 817DD2ED 93D10EE4 2C1686AB 4B4C6AAF
+		893269EB EDEBFD52 BF5BB7AF C0C3B256 7BF4185B 10D73EDE 66AA7BEA
+		FBC82974 6E3934B3 C40FFED6 E27E0A54 23A10812 E90A22DD C4A0F28F
+		C63961A6 E2542665 CC823FAD C333D6CE C1279E5A 4F8BE362 4F745381
+		070545EE 936C6A2A 1BB7B002 EBE9B09E 2B2A604B 10D0D807 E2BF7E31
+		21C742AA C3311ADF C9BDB193 53F76A63 2636917C F7D3D3E1 DE95E5CF
+		EE2B30C7 86F4FC78 050AB401 2DC3A851 0BFF9505 58C70EE9 F7AC4F4E
+		4F19D794 346AA429 9FBF0A53 9DC8C66D D94C76FB B0CDC9A0 747017B1
+		49CFC53D 1053B1B0 FEC72499 3253C964 63308D3C 58CE417F 1D79DBC4
+		7C12780E 1AA56FBD 6F407D36 EC4961E3 B656C682 98A611D5 9938CF8E
+		9A1B421C EF804139 BAE9C2CC 182ED0D1 D660C743`))
+	B = util.Must(util.BytesFromHexString(`
+		D8EF080F 7AE33457 66A5B275 473F03EB EBD791C0 F9FE75C0 A0320FB8
+		301AE625 24F7EFDB 0296B073 AF29404F EF8E39DA 5BFF3F2A A758701B
+		6F3D94D9 1BC0BE28 22C4EC5D B6D42876 9F77C250 F96B3837 4FC1339E
+		88B2C908 3F5C12AA 9AC6A1CA EB7D05B7 285E7F73 122449EA E5F6256B
+		051A044C 8B56D498 609E45ED 98CE7DE8 B372A084 BD335A27 16FB1947
+		7960C182 62F28B45 30DB42A3 10CD80F2 5E1F47E4 12B233F7 6B15ED1A
+		EDB1C9CB 1E89A4C4 2240A8CE 2F0819AA 39099C36 0953DA41 41FCF26F
+		4D6E84FD C8CE6CC4 D7F60B63 E53DF4F3 7B8F0E49 E46372DC DB5673F7
+		7A3FDC79 6DEF5A80 32972470 C7467ECA 12ECD57D 37C13998 3C478050
+		91AA0B90 3846773C D15FF142 1411A4E4 68D91E17 DEBB4558 84F5B48C
+		CB358849 71819768 98C4C259 659E4920 50964DCE 49A16C3A 7784CF0D
+		11E33F65 82BCC3C7 B523BA47 A8013B3E 6879DA1E 18187891 B921C2A4
+		A865ED52 BA89708A 0039D1D9 5400C22D D5C3212E 824C6CF0 9A671B2C
+		FB7E74E5 4ABBF1B3 4A3FB6AD B678830B 70BE20A5`))
+	Key = util.Must(util.BytesFromHexString(`
+		BD5991C4 67908EDF 663BC8D4 1B3E0CA9`))
+	Signature = "MdAbGdvCcN/3lAiDocjIUDViv3c8UfEQFSZpraSIRg4="
+)
+
+// DEVICE_SRP_AUTH Test Vectors. The device password and salt are generated
+// from the bytes 0x00 to 0x27 and 0xf0 to 0xff respectively.
+var (
+	DeviceGroupKey = "-a1b2c3d4e"
+	DeviceKey      = "us-east-2_0123abcd-4567-89ef-0123-456789abcdef"
+	DevicePassword = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8gISIjJCUmJw=="
+	DeviceSalt     = util.Must(util.BytesFromHexString(`
+		00F0F1F2 F3F4F5F6 F7F8F9FA FBFCFDFE FF`))
+	DevicePasswordVerifier = util.Must(util.BytesFromHexString(`
+		00B43728 01A09489 53B6A51C ED39D040 E159C86A 2A9DD651 61B8B2B6
+		B1C4DA36 30DCF60E A168087B 7522D539 815BA9F8 BBAA0191 00F71524
+		A75C965F CD2411EB 10C15A61 76ECDFCA 1955C836 DDC16EE3 DEBA445E
+		7B8F41D2 74A4A18F D1D87509 419163B1 38340767 E274B0A6 32F3FE92
+		06A2021A 9F9FC7E2 D01472DC 9BCDF8C6 B3B34CD1 D243978A E680A63C
+		157169B4 CED06C50 4CE684EB 9C3EA664 310ED876 FF34DF06 58ED9684
+		35CFF247 FB22E0C3 7A1CA7E2 02EBBD3C BD021744 D125D3FF 40DC3C93
+		9E389291 1F59AB34 471BF95A ACE69DA8 5EE28F11 918ED53C ABA324DC
+		E7D4A2B2 2DC212A8 B754F2B5 78549AB5 02B38FB1 AE56D20C A7A19F64
+		43FBC4F3 FFF40F52 B8E92900 09113978 7CECDBB3 2624F96E FC62208E
+		5B3830A6 4474B3F4 55FAEB05 F4A89007 E80D1617 BF660A1D EA08477A
+		99B21FEB F079F133 7E8A59D7 416509C8 9149EB91 8F55CF35 950FE183
+		1D568353 1BCF786B B86D06E9 AA24842E 15C4B5F3 E3893DB2 01C2B834
+		C0120067 2D37CEAB A1D24F75 B0A9F903 BD7D1425 D7`))
+	DeviceB = util.Must(util.BytesFromHexString(`
+		7A73B629 D907D8B7 8207B4F6 CBA5AC3C FCED47AD 02A5D114 68BC6D98
+		1B32FF06 3B5D9B4E 4983F369 324E503D 3BF1B035 313D429F 51A127A7
+		87CEB710 3872450C 0A955318 1CD8CCA6 5BE57EBB B826F483 1903BE30
+		EC75152B A8CDB639 45E00A58 518582F6 DF1DD4B4 49BC090D 44BA26C3
+		C8D413B5 D79B212C 346422DC 29F250CD 6E0DF69F 2FCE18BC 1A174E9F
+		86466EA6 874B7B98 9025C09C FC4A8FC8 583FAA0F 861FDF18 4D762C05
+		B2E55115 5C1479A0 DB50F961 6266DD42 10B30F9D 77F97323 8F31DC0D
+		42327F62 E7D563CC 7F983B5A BF29F825 34A654FA B6DF9691 7D96B829
+		A64F1385 C7FEE5EB EF0943B4 A8B2BE60 A09328AC 60C74040 F1D733FC
+		5581FA69 89767B67 DFE323DD 9D1167D4 84719A94 C5DFA3C0 E32A2063
+		1964B4DF 0A60CBA6 560C4B00 957965FC 026580D8 BE95B382 40320164
+		98BED69D 951A6260 8EE43055 FC734E11 02D83FF4 1A94CF71 B067C209
+		13AA072E 25BDCEF8 A25F5C13 78B9D172 51B7EC95 8F9D5407 E7400B6B
+		922AF497 CB13D445 2B7CBFE0 955C54A1 148A8774`))
+	DeviceDerivedKey = util.Must(util.BytesFromHexString(`
+		DA697315 227AE828 EEE63446 A371F554`))
+	DeviceSignature = "JROTn43FJdlR5cXF5wJj8XkqLtcSDtAc8gYBU6Z4FKY="
+)
@@ -0,0 +1,45 @@
+// Package ecsrp5 provides EC-SRP5 test vectors for MikroTik RouterOS using
+// SHA-256 and the Weierstrass form of Curve25519. No published vectors, or
+// captures of an exchange with RouterOS or an existing client, were available
+// so they were computed with an independent implementation of the protocol as
+// used by RouterOS and do not prove interoperability. They should be replaced
+// by a captured RouterOS or Winbox exchange, or values from the MarginResearch
+// reference code, when one is available. Elements are encoded as the
+// Montgomery u-coordinate followed by the parity of the y-coordinate.
+//
+//nolint:gochecknoglobals
+package ecsrp5
+
+import "github.com/bodgit/srp/internal/util"
+
+// EC-SRP5 Test Vectors.
+var (
+	Identity = []byte("admin")
+	Password = []byte("password")
+	Salt     = util.Must(util.BytesFromHexString(`
+		0A1B2C3D 4E5F6071 8293A4B5 C6D7E8F9`))
+	Verifier = util.Must(util.BytesFromHexString(`
+		1BB84173 5825473D CA5F968F C262A8A7 AFF05A8D 79E0BD79 3D367686
+		A7A7E0A5 01`))
+	PrivateA = util.Must(util.BytesFromHexString(`
+		1F2E3D4C 5B6A7988 9796A5B4 C3D2E1F0 0F1E2D3C 4B5A6978 8796A5B4
+		C3D2E1F0`))
+	XA = util.Must(util.BytesFromHexString(`
+		01031F3B CDB51F6A 36B4423D 0A2002CB 7041E188 0DF14C5D 0EBECE64
+		E86E9CAF 01`))
+	PrivateB = util.Must(util.BytesFromHexString(`
+		6C5D4E3F 2A1B0C9D 8E7F6A5B 4C3D2E1F 708192A3 B4C5D6E7 F8091A2B
+		3C4D5E6F`))
+	XB = util.Must(util.BytesFromHexString(`
+		23DB80B9 1D2E6FD3 DCA0A3E2 D0555173 C7FB46B9 E6FC34B6 15DAA429
+		42EE639B 01`))
+	Z = util.Must(util.BytesFromHexString(`
+		2B40B440 DDC2B63D 25FC8F6F D6C14AB1 2DBC9C91 BC69D64D 8D893CF8
+		55FC36D7`))
+	M1 = util.Must(util.BytesFromHexString(`
+		65FFBE21 A7B10309 6B38ED2D E337EE0C 56EB5652 08B4D0F9 F8E1446E
+		0D79BECC`))
+	M2 = util.Must(util.BytesFromHexString(`
+		DEA9C93D 5001A138 7978525C 6707A66C A95D4799 BEAE4DDF 856CC11E
+		14AE7944`))
+)
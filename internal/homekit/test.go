@@ -0,0 +1,151 @@
+// Package homekit provides the SRP test vectors from the HomeKit Accessory
+// Protocol specification. They use the RFC 5054 Appendix B inputs with the
+// 3072-bit group and SHA-512. The specification does not include the M1 and
+// M2 proofs so these were computed from the same inputs with
+// github.com/tadglines/go-pkgs/crypto/srp, which is used by
+// github.com/brutella/hap to pair with Apple devices.
+//
+// None of the published values have a leading zero byte so they do not show
+// whether A and B are padded. The padded vectors use private values chosen so
+// that A and B both have a leading zero byte, the proofs were computed with
+// the same package by passing it A and B padded to 384 bytes and K computed
+// from the padded premaster secret, as the HomeKit Accessory Development Kit
+// does. They have not been checked against a real exchange.
+//
+//nolint:gochecknoglobals
+package homekit
+
+import "github.com/bodgit/srp/internal/util"
+
+// HAP SRP Test Vectors.
+var (
+	V = util.Must(util.BytesFromHexString(`
+		9B5E0617 01EA7AEB 39CF6E35 19655A85 3CF94C75 CAF2555E F1FAF759
+		BB79CB47 7014E04A 88D68FFC 05323891 D4C205B8 DE81C2F2 03D8FAD1
+		B24D2C10 9737F1BE BBD71F91 2447C4A0 3C26B9FA D8EDB3E7 80778E30
+		2529ED1E E138CCFC 36D4BA31 3CC48B14 EA8C22A0 186B222E 655F2DF5
+		603FD75D F76B3B08 FF895006 9ADD03A7 54EE4AE8 8587CCE1 BFDE3679
+		4DBAE459 2B7B904F 442B041C B17AEBAD 1E3AEBE3 CBE99DE6 5F4BB1FA
+		00B0E7AF 06863DB5 3B02254E C66E781E 3B62A821 2C86BEB0 D50B5BA6
+		D0B478D8 C4E9BBCE C2176532 6FBD1405 8D2BBDE2 C33045F0 3873E539
+		48D78B79 4F0790E4 8C36AED6 E880F557 427B2FC0 6DB5E1E2 E1D7E661
+		AC482D18 E528D729 5EF74372 95FF1A72 D4027717 13F16876 DD050AE5
+		B7AD53CC B90855C9 39566483 58ADFD96 6422F524 98732D68 D1D7FBEF
+		10D78034 AB8DCB6F 0FCF885C C2B2EA2C 3E6AC866 09EA058A 9DA8CC63
+		531DC915 414DF568 B09482DD AC1954DE C7EB714F 6FF7D44C D5B86F6B
+		D1158109 30637C01 D0F6013B C9740FA2 C633BA89`))
+	XA = util.Must(util.BytesFromHexString(`
+		FAB6F5D2 615D1E32 3512E799 1CC37443 F487DA60 4CA8C923 0FCB04E5
+		41DCE628 0B27CA46 80B0374F 179DC3BD C7553FE6 2459798C 701AD864
+		A91390A2 8C93B644 ADBF9C00 745B942B 79F9012A 21B9B787 82319D83
+		A1F83628 66FBD6F4 6BFC0DDB 2E1AB6E4 B45A9906 B82E37F0 5D6F97F6
+		A3EB6E18 2079759C 4F684783 7B62321A C1B4FA68 641FCB4B B98DD697
+		A0C73641 385F4BAB 25B79358 4CC39FC8 D48D4BD8 67A9A3C1 0F8EA121
+		70268E34 FE3BBE6F F89998D6 0DA2F3E4 283CBEC1 393D52AF 724A5723
+		0C604E9F BCE583D7 613E6BFF D67596AD 121A8707 EEC46944 95703368
+		6A155F64 4D5C5863 B48F61BD BF19A53E AB6DAD0A 186B8C15 2E5F5D8C
+		AD4B0EF8 AA4EA500 8834C3CD 342E5E0F 167AD045 92CD8BD2 79639398
+		EF9E114D FAAAB919 E14E8509 89224DDD 98576D79 385D2210 902E9F9B
+		1F2D86CF A47EE244 635465F7 1058421A 0184BE51 DD10CC9D 079E6F16
+		04E7AA9B 7CF7883C 7D4CE12B 06EBE160 81E23F27 A231D184 32D7D1BB
+		55C28AE2 1FFCF005 F57528D1 5A88881B B3BBB7FE`))
+	XB = util.Must(util.BytesFromHexString(`
+		40F57088 A482D4C7 733384FE 0D301FDD CA9080AD 7D4F6FDF 09A01006
+		C3CB6D56 2E41639A E8FA21DE 3B5DBA75 85B27558 9BDB2798 63C56280
+		7B2B9908 3CD1429C DBE89E25 BFBD7E3C AD3173B2 E3C5A0B1 74DA6D53
+		91E6A06E 465F037A 40062548 39A56BF7 6DA84B1C 94E0AE20 8576156F
+		E5C140A4 BA4FFC9E 38C3B07B 88845FC6 F7DDDA93 381FE0CA 6084C4CD
+		2D336E54 51C464CC B6EC65E7 D16E548A 273E8262 84AF2559 B6264274
+		215960FF F47BDD63 D3AFF064 D6137AF7 69661C9D 4FEE4738 2603C88E
+		AA098058 1D077584 61B777E4 356DDA58 35198B51 FEEA308D 70F75450
+		B71675C0 8C7D8302 FD7539DD 1FF2A11C B4258AA7 0D234436 AA42B6A0
+		615F3F91 5D55CC3B 966B2716 B36E4D1A 06CE5E5D 2EA3BEE5 A1270E87
+		51DA45B6 0B997B0F FDB0F996 2FEE4F03 BEE780BA 0A845B1D 92714217
+		83AE6601 A61EA2E3 42E4F2E8 BC935A40 9EAD19F2 21BD1B74 E2964DD1
+		9FC845F6 0EFC0933 8B60B6B2 56D8CAC8 89CCA306 CC370A0B 18C8B886
+		E95DA0AF 5235FEF4 393020D2 B7F30569 04759042`))
+	U = util.Must(util.BytesFromHexString(`
+		03AE5F3C 3FA9EFF1 A50D7DBB 8D2F60A1 EA66EA71 2D50AE97 6EE34641
+		A1CD0E51 C4683DA3 83E8595D 6CB56A15 D5FBC754 3E07FBDD D316217E
+		01A391A1 8EF06DFF`))
+	PremasterSecret = util.Must(util.BytesFromHexString(`
+		F1036FEC D017C823 9C0D5AF7 E0FCF0D4 08B009E3 6411618A 60B23AAB
+		BFC38339 72682312 14BAACDC 94CA1C53 F442FB51 C1B027C3 18AE238E
+		16414D60 D1881B66 486ADE10 ED02BA33 D098F6CE 9BCF1BB0 C46CA2C4
+		7F2F174C 59A9C61E 2560899B 83EF6113 1E6FB30B 714F4E43 B735C9FE
+		6080477C 1B83E409 3E4D456B 9BCA492C F9339D45 BC42E67C E6C02C24
+		3E49F5DA 42A869EC 855780E8 4207B8A1 EA6501C4 78AAC0DF D3D22614
+		F531A00D 826B7954 AE8B14A9 85A42931 5E6DD366 4CF47181 496A9432
+		9CDE8005 CAE63C2F 9CA4969B FE840019 24037C44 6559BDBB 9DB9D4DD
+		142FBCD7 5EEF2E16 2C843065 D99E8F05 762C4DB7 ABD9DB20 3D41AC85
+		A58C05BD 4E2DBF82 2A934523 D54E0653 D376CE8B 56DCB452 7DDDC1B9
+		94DC7509 463A7468 D7F02B1B EB168571 4CE1DD1E 71808A13 7F788847
+		B7C6B7BF A1364474 B3B7E894 78954F6A 8E68D45B 85A88E4E BFEC1336
+		8EC0891C 3BC86CF5 00978801 78D86135 E7287234 58538858 D715B7B2
+		47406222 C1019F53 603F0169 52D49710 0858824C`))
+	K = util.Must(util.BytesFromHexString(`
+		5CBC219D B052138E E1148C71 CD449896 3D682549 CE91CA24 F098468F
+		06015BEB 6AF245C2 093F98C3 651BCA83 AB8CAB2B 580BBF02 184FEFDF
+		26142F73 DF95AC50`))
+	M1 = util.Must(util.BytesFromHexString(`
+		5F7C14AB 57ED0E94 FD1D78C6 B4DD09ED 7E340B7E 05D419A9 FD760F6B
+		35E523D1 310777A1 AE1D2826 F596F3A8 5116CC45 7C7C964D 4F44DED5
+		559DA818 C88B617F`))
+	M2 = util.Must(util.BytesFromHexString(`
+		2FA0E81F 5CB73B88 FA096427 0F321DD6 41F2227A 5D805C40 F1BFE96A
+		AF6A19FF CE8E2328 7965A39E AB9D5A02 215F89E1 28177ED2 C4F103E6
+		55A04553 1BCBF7AD`))
+)
+
+// Padded HAP SRP Test Vectors, using the RFC 5054 Appendix B identity,
+// password and salt.
+var (
+	PaddedA = util.Must(util.BytesFromHexString(`
+		D9CB9F8D 19D09BA7 E204B083 F4CC59C9 A4F3530A 76EDD0A4 7CB70536
+		F7888A1C`))
+	PaddedB = util.Must(util.BytesFromHexString(`
+		9BFEA10E 1D03FC0A F5AA162B DF0EDB99 CC31260B B849FAD7 CC61440A
+		8341C971`))
+	PaddedXA = util.Must(util.BytesFromHexString(`
+		0041F402 E4079623 B0C19820 A4A0A193 7E8BD8B1 A7EBE417 BDE57527
+		D342997A 8F8F05FA 21F3D151 D623A456 68C0CB11 7386CA6D 3F8FFD7B
+		9C59A033 DC367027 125EDC89 77EF26F5 749BCAE2 B10A4C38 C5BCF499
+		21D79022 260B52A4 F9D3F786 716BB269 B536F41E A8DE97A0 0CCCD13D
+		4561BA64 E28D216A 08DDBAAF 939A56F2 6A11F0A5 DA9A56DA 7E514D7C
+		DB054063 C1F35806 B60D3966 A0CFB8A5 BE9827E7 ED9CBB14 3482B524
+		51308E24 CE36E70E C999197E 6072E091 0C996C51 01E67A68 B13ABAC4
+		E978D7CD A54417E5 6742B679 C1FB75DA 40529B40 19B28BE4 481A7F20
+		CCB4E77C 7E892D64 1B4FE2EE EC271CFB 0D3878DD 4A782031 87DF21CE
+		4AF72083 F0F1D493 175F8F5B FD30468A 758FB809 EE0BA6E4 0B2999CA
+		4EBE3AF9 77C233B9 2BDF68E8 57A1D19D ED22C71E A21DDEB2 F7AC6724
+		F96ACCE4 8965952B 4BDB1609 AF07238F EB97ED70 201A85C4 6743ACD2
+		5E8B8B50 0A92FB96 229A7F6D 3DBC1CED 5BCC9440 1B20B4FB 2EAFC90B
+		1C95E6A7 0BEF245B CCFEF929 58B9659F D96F11BA`))
+	PaddedXB = util.Must(util.BytesFromHexString(`
+		000E3EF5 68444754 28A06719 DA69BAE9 E7FC69F0 C002CB77 C9D0AD37
+		72D3E97C 10CB8FC6 8642E838 6625935D 4DEA41C7 B7BAA4FE 1F4BF12B
+		019A777E 202442EA 0C423E86 16A259F0 F29273AB 5C4B00EA 711C1929
+		41E56F85 7D40AA56 B2131D13 F99F7119 00C0EB3A A252957F 46950407
+		D8A598D8 1C76431B ABDF8984 7647FDF3 2203C370 B0E2E91C A46A84B2
+		96C0B474 A88E2815 8164835F C824C794 6A29AF84 F6B0E0FE C4015F69
+		8509F8A9 06BB224D FF09F46C 38BA9885 54ABACB9 5BDB0927 B90728A1
+		7C25E663 D1600A62 DC222C20 9E3E498D 931E0CD0 FC47CF88 7370C439
+		B6A4E9F1 334495C9 3C05DA7A DAC9DD62 116CA1E4 02DEB6CD D860F56E
+		520FBFDB C8DB401C 6C4E80A8 35E61B56 D3510B69 B5602E75 9FE903F8
+		67D37DFD 9B665A84 C57D0DE7 1CA510E4 F9D7364D C2E936D6 C730D291
+		3DA2A224 CF0BBFB0 59E82499 C9058DB8 BFBA3BB9 D32A7D59 894890F0
+		A1661703 3B81A14E 8C178CB9 AEDDC3D6 C9BC09C2 604C4369 7092C8E8
+		77FE740A FD313B9E 22888090 0FCEE0D5 AAD55514`))
+	PaddedK = util.Must(util.BytesFromHexString(`
+		3DB6508A 69C44BBA C1B9D9AD 45ED6A93 D8312A84 CBC26BC1 BE61C694
+		F4044AFC 42B0210D 1BF1184C 010FD609 76474705 F2E5E746 546955BB
+		B2E9A575 3869F160`))
+	PaddedM1 = util.Must(util.BytesFromHexString(`
+		AFE2B2A4 AA637E7F 970205BB 0D905BB2 006F2033 3C7E2831 21940360
+		ED9090A6 948EC168 920FA379 5B9A7578 49B210A5 8CFBC8DE 5EB1231F
+		C33F3D1B D4D6672A`))
+	PaddedM2 = util.Must(util.BytesFromHexString(`
+		D5E6293B 13B443E9 829DAEBB FA5F837E 27659D16 F9144DF7 8D5F7486
+		0A275A9D F9CF242D 1F9F0B25 3E4D7E10 AA3A8761 D58DC212 ED225479
+		097D998B 7652B0F9`))
+)
@@ -0,0 +1,60 @@
+// Package legacy provides test vectors for the legacy SRP-3 and SRP-6
+// protocols. There are no published test vectors for either protocol, neither
+// the original papers nor srp.stanford.edu include any, so these are computed
+// using the RFC 5054 Appendix B inputs with the 1024-bit group and SHA-1. The
+// X, V and A values are unchanged from RFC 5054 so only the values that
+// differ from SRP-6a are listed.
+//
+//nolint:gochecknoglobals
+package legacy
+
+import "github.com/bodgit/srp/internal/util"
+
+// SRP-3 Test Vectors.
+var (
+	SRP3XB = util.Must(util.BytesFromHexString(`
+		4FBA67DE A3C883D5 A4FB3681 EF7981C5 EAFECF74 A1018E3C 41E2E2DD
+		1B046BE0 B34BE45E 6171B359 CADA5163 665FF801 742B24C1 E54DF0A5
+		73970EA2 DEE42598 9BAF66F2 4E258075 0CAC9D29 1C4BAF57 BD3E5FAD
+		60407215 E99DABA6 2C1229E2 FCEE0415 0DE96C78 779464AA F7D3BBF4
+		6B994FB2 01263087 245E08A5 84DA173B`))
+	SRP3U = util.Must(util.BytesFromHexString(`
+		6BD93C1B`))
+	SRP3PremasterSecret = util.Must(util.BytesFromHexString(`
+		2128B1D3 A26FC3FC 7A3DD955 10DD5B5F BB7FC69C AE3B76B8 6F83EE1F
+		663E1D49 D72E56B2 05FB418D 1CAB4166 00460159 DF39602E C22C932F
+		40FC3893 EBFDB638 F218656A 794F2068 D3212764 02B7422E 8EB563B6
+		8D3E1FD3 FC5E3CFF 86E59FBD 167CEF34 FF3D7D88 90A679D2 E0DA9DBE
+		6FD349C2 A18E8DDA FA4DA967 337296EE`))
+	SRP3K = util.Must(util.BytesFromHexString(`
+		6125E5C4 E4116F0A 64862048 F6D8ACD8 6B13A2C0 A8B71F5F 37937000
+		5E8000EC 7CBD20D3 E37F4F7F`))
+	SRP3M1 = util.Must(util.BytesFromHexString(`
+		8E8F4314 BFC6A8E9 728983D5 CCC3DBE4 4A1385E2`))
+	SRP3M2 = util.Must(util.BytesFromHexString(`
+		39070298 3D821297 88499F7A E891069B 242C839F`))
+)
+
+// SRP-6 Test Vectors.
+var (
+	SRP6XB = util.Must(util.BytesFromHexString(`
+		5D59D8F5 C8F4EE9D A52E3882 5E50A75A EC48309A 68219245 D44E61B5
+		B63A4A4C E11B4C7D FF47E82C AF0E4098 750CE9D4 7560B4D0 913772AC
+		722FC339 A6E9B51B CF85C21F 56C788C6 3F959219 32107F10 E0A9D9EF
+		B918FC48 5A72C5A5 39B8AE00 54B1A27C A15C7F4A 4E364552 ACF52C4B
+		40F36278 BAA551B7 3AEFB1A1 8802464E`))
+	SRP6U = util.Must(util.BytesFromHexString(`
+		DECF10CD 6CCA5869 A6083C88 58CC3D60 B3A5E33D`))
+	SRP6PremasterSecret = util.Must(util.BytesFromHexString(`
+		2528D2B8 955D62F0 C3141F32 E621CA2F 7D839A71 B165EF43 E1A18B24
+		BFE81710 84D18213 8BDB09C8 0801235B 5CD9EBF7 0C5790F0 5C860FD7
+		A2E0C72D 5B5E86D2 F3297019 EFE4EC49 0962743F 483AFEB4 60F93DA8
+		B261E370 591C6B1B 1057E29B 8980C7EF 71ADD1B8 26E90790 2EA72C98
+		7130BAEC 4190C363 F0A89140 4A559FF6`))
+	SRP6K = util.Must(util.BytesFromHexString(`
+		A57C9793 093100E1 98CD66ED CA07007E 7D4B011E`))
+	SRP6M1 = util.Must(util.BytesFromHexString(`
+		184E191B 846710DF 8FCB8A6F BA4CB407 8C464EC1`))
+	SRP6M2 = util.Must(util.BytesFromHexString(`
+		042900FD 2AC90B54 53F0CB61 4CA773AE F8762AA4`))
+)
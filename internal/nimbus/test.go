@@ -0,0 +1,40 @@
+// Package nimbus provides test vectors for the default routines of the Nimbus
+// SRP-6a library. They are computed using the RFC 5054 Appendix B inputs with
+// the 1024-bit group and SHA-1; the A value is unchanged from RFC 5054.
+//
+//nolint:gochecknoglobals
+package nimbus
+
+import "github.com/bodgit/srp/internal/util"
+
+// Nimbus SRP-6a Test Vectors.
+var (
+	X = util.Must(util.BytesFromHexString(`
+		BF56D7DF 933FF138 C4ED956E 26D2576D BBE8530B`))
+	V = util.Must(util.BytesFromHexString(`
+		344F98FD D71980B0 4505183B 35243094 F155EDE6 B8C2FA72 FA0293B4
+		D3B71595 983D9508 BA1302EB 42365992 304DC192 F3CC0CE2 BDDC3310
+		ACB73819 7A32E392 960427F2 75CE9D7C 033ADBD1 476359A6 90292097
+		FCAC8BB2 5962F581 C965A06F 2A2EE8B4 2C4A9ACF 4E432EFF 03EED00E
+		48640999 88F4E103 24A0A026 73C7C7CD`))
+	XB = util.Must(util.BytesFromHexString(`
+		C7BF95EF 199BE6CC 5B574EBD 783C7039 951FD548 885CB5E4 CADCBC9A
+		CD0F35FB D0F0A7E4 B0CFC365 BBB4FB82 5E16BAA4 6121D05F A53E82EF
+		1E750517 4BA2D722 D247D2D1 6F794BB8 1DB5A865 09FD1FB5 E6A03D79
+		73061236 A72425DE 2DD8EB72 513635D6 01AE2197 A06D3CE6 B2C95CB7
+		62BB7EBB 86C68CDF 7CA59943 1AE4B2B1`))
+	U = util.Must(util.BytesFromHexString(`
+		ACDB8522 EB528CA5 904D557C D47DB253 16D1A67C`))
+	PremasterSecret = util.Must(util.BytesFromHexString(`
+		912285DA CE4A2BB3 3E144DC3 C657703F F2C5C1DD E2D506A0 E7026457
+		39FE1622 1AAF7879 055B97A6 32455959 A47EB204 53706B55 D0E48E65
+		68D6DCCA CBE1F168 53DC17D0 525CD0A6 6F1B8874 2B38F800 F73B34B6
+		BE967D37 C17C8E7A 030333E8 232F7A6E 4EFC9660 BBBA994A C6E98DEC
+		7056C582 13F574C7 0908CEDB D645403A`))
+	K = util.Must(util.BytesFromHexString(`
+		F9766DCC 3D9882F0 0F84858E 8E1BDA61 56D10D5E`))
+	M1 = util.Must(util.BytesFromHexString(`
+		FE82C3D7 ECCF3DD6 7F30052E 740F6C6C 4C966008`))
+	M2 = util.Must(util.BytesFromHexString(`
+		AF03D6D7 8AB27097 19B46264 8D84DB3D 2A9404AC`))
+)
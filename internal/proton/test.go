@@ -0,0 +1,115 @@
+// Package proton provides the SRP test vectors from the ProtonMail go-srp
+// library using auth version 4. The private value a was recovered from the
+// seeded random source used by its tests. Numbers are little-endian apart
+// from PrivateA which holds the random bytes read for a.
+//
+//nolint:gochecknoglobals
+package proton
+
+import "github.com/bodgit/srp/internal/util"
+
+// Proton SRP Test Vectors.
+var (
+	Password = []byte("abc123")
+	Modulus  = util.Must(util.BytesFromHexString(`
+		5B6CF91C 18BC46FB 1F633653 4BBA8169 4C713E1B 1F1C9159 A6EDCA77
+		AB3525A7 CDAC2087 F6B7EF3C BADFBAAA 1CC563E0 5831F647 C9DE2BB3
+		E436F8F1 B68F534A DB98E73E E3033591 BC558DF5 C42100A9 1D5A0CB4
+		258EB2C1 59F6DBE1 C5A45E1C 61EB07AE A1482943 C3312488 9568C156
+		610967F3 2EB03E38 653DBDC5 C3EB3AA7 99BF0B42 7DF2AD18 02232D83
+		D92046D9 2A70CCD4 39B7AB62 BFC52E61 FB17618E 79DD86CA E4C26F54
+		F68C80DB 0C734971 4BFA975F 8F67CE75 1C2E4799 D6D09D8D 16BAB790
+		98E4466A 4EFC67A7 AB5516F9 31249C85 806FA573 249240DB 8E0AFC7F
+		1C9D2C76 FA748D64 EDDB6E8E 98B36DBC AEE57D03 C9BA0E1C 664BE6E2
+		84DDEDB5`))
+	Salt = util.Must(util.BytesFromHexString(`
+		C8A95CE7 F0AF39B7 E88B`))
+	X = util.Must(util.BytesFromHexString(`
+		507C325C BEF5F184 D85A24D8 87CFCB3C B4816BB0 E07B32F9 4095D912
+		0CC479EA 260F431F EB666EEA A364423D 33FEC0F1 7F77C819 39F453C8
+		24E23582 04B32799 8E633F0B D35FADD2 CAF996A4 5E8B88C8 56BF3E9B
+		51987EE7 3FE11908 88FD8751 1380D6FB 63D70269 C6409675 3204E8B8
+		39CB2DAB C6BAF4AB 0B4AB3CC 8DFB40D5 03C80B45 5B0AD7C1 B36A8695
+		946B53D0 0C9FA01E 94239C25 DAEDD565 A63E5341 B0EF3C46 7D26B03B
+		737ED3A2 920D7ABA C1994FE3 85C3D416 9F041F0B 1272E471 C4305722
+		46013F58 08553B49 4933CB5D 628996B7 6A3731EE 257C4EAE 978E8838
+		EE203161 58ADAFFE 05DBC9FC 29E77A61 F3454F77 77913D05 A90A43B7
+		8AA29652`))
+	V = util.Must(util.BytesFromHexString(`
+		3A811D56 407EFFAC 4433B71F DC94821E 4FC64E12 B586C8EE A4BBA487
+		5AD01873 ECDA5894 DB9DBE42 1FA25DA7 4118C588 DE8FC3E8 401AD58C
+		F65FC32C 47CFC152 29D72A42 04407ECE A519B15C 0B48BC26 9676DBCD
+		15B7E3FD BCF0E649 B01312F4 3388D5C1 32FCC004 5E2F3CC7 8E8566F2
+		49DBF8CA 7FABE844 66175AB8 D92B05DF 44B756E2 27055EF2 519DC4F7
+		2C415919 CE9B8A5A 1610E997 63880C1C 6531286E D5075155 F5920AAE
+		E74F5B4C EF33DF82 DC26A824 4629D4DF 266E5215 430CD94F F0154814
+		4F80A038 ABCE717A F1EADD18 F6F4619C 8BC12409 77B03665 DB72F86D
+		6B13F1ED EB25F510 5B3BA9B0 C99873BC 0DDAF325 1D274367 FD2DEC6A
+		00E45D97`))
+	PrivateA = util.Must(util.BytesFromHexString(`
+		538C7F96 B164BF1B 97BB9F4B B472E89F 5B1484F2 5209C9D9 343E92BA
+		09DD9D52 DFD79B4D 76429B61 7A0C9F9F 0D3BA55B 0CC0D614 4C888535
+		841ACBE0 709B0758 083F61D3 75BC02B4 1DF4F919 29E18FDA 9E6F82E5
+		4E748E81 E79E4BBD 6FE34CDC BA843EE8 D63E8C4F FE1CEBEA 546D8FAC
+		13DD1AAC 04CE2EA2 877C5579 CFA2C78E 1B0BAFAE 881B82A7 51108A42
+		ED3C903C AA43465A 78620616 978AED0C E3C6C4F3 AE7BC3E0 495B5712
+		FEFDBE0C 102887E1 00DACD2D 885F692C B607DA00 A11C1C70 71E796A2
+		DC2DC25A 5B74B2E1 29705E27 3F05C923 26828E2B 056E3817 658E1061
+		498947FD F344410E D4C11602 3FA8E357 6B6FED27 FF8974BA C0CAFD9A
+		D05692B1`))
+	XA = util.Must(util.BytesFromHexString(`
+		98AF9E48 CA2C7D93 BF0ACE6C FAF71B8E 9B0DEC5F 1400E6F0 94A2A70B
+		2FF3F45A 68311336 3DF4DEE7 2C0B0607 DF98B61A 6A93EAED 73B16AA6
+		A3D3C91C 64B1DC33 57C80362 506594CA 3E711237 5A80433B 49DFF607
+		308D28A7 AC81833C 7DF34DCE 8C8CE78C 20F60775 C148C387 206E281B
+		4530A1CB 085F7DA1 88C9D894 C324B7C3 46AE2B72 3E59E611 BC694676
+		B77EEF4B 119FAFD2 EA3A3E32 82ACEEFE F44DEE68 C8BAE076 9D5C17F2
+		C76ADA04 517003EC A1EC5BC4 ED4FA197 70097251 2B7B877C A667E131
+		DC57CDED 6CA0923C 7CCBAA69 AD3BAD8D 2BD2B179 98B2D3BA A4C81911
+		64535A86 F65A2AF7 E20CB12C 2092DD4F DE0856EB 724AED8E 8B59D0F2
+		D1DE9C02`))
+	XB = util.Must(util.BytesFromHexString(`
+		975DC841 25450445 74659444 B9143866 03FA3A91 A221F223 6D20D840
+		6DD8A77F 45913D81 FE4DE7D5 9870AAB0 1DCBEAAF 3CF16AFE 57B46FB5
+		03B5ACA8 5F868E4C 98A1A11B E27DAB3C 1EBDE271 DF67007A 9DD13501
+		6E46644F A232E94A 739A2AC2 5D0B4486 F19021D3 8BBB0BF2 FAF65687
+		6E06D9A8 4133BE5D 02AC243F DEB51093 21EBBFC8 E4F03984 97506637
+		4F4F996E 0B255AEC 14B577D6 5D9AE474 FD50A350 E7496D88 E0B481FB
+		050A19B3 E29A7238 5437C832 6EEF9FB3 272F4CAD DE0BEF66 08DD98E8
+		676CB823 2E477A45 074445F5 E29A7BB7 00E916C9 7C5D5B8E 6D2735F5
+		8B5F380A 82E28139 E1771840 2C9C280A EBCABCDB FA6AF5EB 625038B3
+		52550170`))
+	PremasterSecret = util.Must(util.BytesFromHexString(`
+		D20C806D 8C157C98 01201F0B 7787EF1A 710924CA D7755E61 D4230F9E
+		FE1BBE62 7BE64060 F8DB7720 E225351D 2364E8A2 99737E36 3F16625C
+		391A94CD 42F5CAB8 AF44470B F284E48F 49DDB7C8 3BEB7CF3 2E833DDA
+		E5B231C2 3125385F 8AE297E7 93274A9C 214ED932 8FDC37B1 49896D44
+		3E88298E 9A0A35D2 016C1BF8 3346BFB4 2CE3AA9B 9D14784A 219AB178
+		8279E607 41DAA539 0AAE50F6 ED9265A9 2A05B60C D6D8708A BDBBD6E3
+		65AF12E2 DC597E43 A5DAEC68 E37B143A B761A2D5 429DC002 567E7408
+		68C192A2 43627DAA 6265E0AD 2C5005CA 5A07E184 F5F6BBFB 5DB126B4
+		C573663D 1F92521E 4FC1C551 BC369D18 DB37EA15 E4F3F400 8A82BD2C
+		D0F2A206`))
+	M1 = util.Must(util.BytesFromHexString(`
+		41BFB5FA 312A1D1A A92779C4 257D8512 3D245E00 885879E0 3B4793E1
+		1D88764C 247BF71E 08852641 AD117D36 14E77C9B 3B50157A B82D6FB3
+		745BFDDB 6AD85D1D 3F450078 72D2F0C6 40383FC8 F9EE5277 AA57BE8D
+		B94FA940 79CEF378 1C8E6663 0EAFF3BD 14520FB7 6D20E451 2BDEF185
+		D47D5BD6 C6E57446 AF673D65 EBF8D185 5C5E0522 3541BFD3 31D22907
+		C4854A4C 2B175AB8 AB460BE7 599D9CD4 EB1694D2 ABEE9581 2CADA6B0
+		BAB45FD1 BD555F0A 2133A284 E76163E7 1952D855 99DCC834 588382E4
+		A5B9CE22 3A422AC4 5032B6A4 7FD1C6FB B81DC5BD 68CBA9A5 498793D0
+		66EA4760 F7DA37BE 5D06ECE8 26108EF2 EA2C8462 65EA1F8E 1718E449
+		DAC0CCC5`))
+	M2 = util.Must(util.BytesFromHexString(`
+		48B09220 2962A120 2DA336AE 659CDC26 E54FC98F 8C8E7C5F 2528047B
+		DCBA45A7 E08E53EA 9E141365 C95128F1 AC121C55 C966A7EC F23384BF
+		9FACFC99 35A13541 A579CDBA B3D727FD 457A46C9 38D9C14D C5B95089
+		A64869EA 28822B75 9DD927A6 C5AC055C 2D3A7600 1D27B237 1CABDF91
+		778C00D3 94521E68 7E39C9B5 7838D8E1 A9E31D65 2A815C1F E21B07F0
+		AF44D402 9914F284 E8812666 72505C31 1D72D3FE 1F8F943D 1749E85C
+		CB26C820 122CB1C4 3B64F54F C50C3806 A96AA18C D38E35CF 2712F179
+		05109B68 3D6CFCD3 FA2CEE47 454E3C3B 63B7E757 AD69F2F7 49369F34
+		B406CD98 BCD22A6C 99F5690C 0566BE36 A7F3B523 D9F94B79 98B4C806
+		93FEDBD6`))
+)
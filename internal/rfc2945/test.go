@@ -1,15 +1,28 @@
-// Package rfc2945 provides test vectors documented in
-// RFC 2945.
+// Package rfc2945 provides self-generated test vectors for the functions
+// documented in RFC 2945, which does not publish any test vectors of its own.
 //
 //nolint:gochecknoglobals
 package rfc2945
 
 import "github.com/bodgit/srp/internal/util"
 
-// RFC 2945 SRP Test Vectors.
+// Self-generated test vectors for the M1 and M2 proofs from RFC 2945 using the
+// RFC 5054 test values.
 var (
 	M1 = util.Must(util.BytesFromHexString(`
 		3F3BC671 69EA7130 2599CF1B 0F5D408B 7B65D347`))
 	M2 = util.Must(util.BytesFromHexString(`
 		9CAB3C57 5A11DE37 D3AC1421 A9F00923 6A48EB55`))
 )
+
+// Self-generated test vectors using the SHA_Interleave function from RFC 2945
+// to compute the session key from the RFC 5054 premaster secret.
+var (
+	InterleavedK = util.Must(util.BytesFromHexString(`
+		2B8CABCE DE81B976 5A37FC68 FBDE5123 26A15651 2BC0DAC5 FD64D2C7
+		C3BF857A 56B0C0A8 CEED18C0`))
+	InterleavedM1 = util.Must(util.BytesFromHexString(`
+		8B5FDB7D B0346E35 3689D2ED FACEC647 A813E6D0`))
+	InterleavedM2 = util.Must(util.BytesFromHexString(`
+		E8149A44 A9D5BF55 2A4CC912 0C545301 A537F227`))
+)
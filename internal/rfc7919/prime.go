@@ -0,0 +1,28 @@
+// Package rfc7919 provides the ffdhe prime numbers documented in RFC 7919.
+package rfc7919
+
+import (
+	_ "embed" // embed
+)
+
+var (
+	// Hex2048 is the 2048-bit prime
+	//go:embed prime/2048.txt
+	Hex2048 string
+
+	// Hex3072 is the 3072-bit prime
+	//go:embed prime/3072.txt
+	Hex3072 string
+
+	// Hex4096 is the 4096-bit prime
+	//go:embed prime/4096.txt
+	Hex4096 string
+
+	// Hex6144 is the 6144-bit prime
+	//go:embed prime/6144.txt
+	Hex6144 string
+
+	// Hex8192 is the 8192-bit prime
+	//go:embed prime/8192.txt
+	Hex8192 string
+)
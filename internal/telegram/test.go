@@ -0,0 +1,68 @@
+// Package telegram provides test vectors for the Telegram 2FA password check.
+// The password, salts, group and srp_B are the inputs used by the tests of
+// github.com/gotd/td/crypto/srp (srp_test.go in v0.100.0), the group being
+// the one sent by Telegram. The private value a was randomly generated and A
+// and M1 were computed from it with that package.
+//
+//nolint:gochecknoglobals
+package telegram
+
+import "github.com/bodgit/srp/internal/util"
+
+// Telegram SRP Test Vectors.
+var (
+	Password = []byte("123123")
+	G        = 3
+	P        = util.Must(util.BytesFromHexString(`
+		C71CAEB9 C6B1C904 8E6C522F 70F13F73 980D4023 8E3E21C1 4934D037
+		563D930F 48198A0A A7C14058 229493D2 2530F4DB FA336F6E 0AC92513
+		9543AED4 4CCE7C37 20FD51F6 9458705A C68CD4FE 6B6B13AB DC974651
+		29693284 54F18FAF 8C595F64 2477FE96 BB2A941D 5BCD1D4A C8CC4988
+		0708FA9B 378E3C4F 3A9060BE E67CF9A4 A4A69581 1051907E 162753B5
+		6B0F6B41 0DBA74D8 A84B2A14 B3144E0E F1284754 FD17ED95 0D5965B4
+		B9DD4658 2DB1178D 169C6BC4 65B0D6FF 9CA3928F EF5B9AE4 E418FC15
+		E83EBEA0 F87FA9FF 5EED7005 0DED2849 F47BF959 D956850C E929851F
+		0D8115F6 35B105EE 2E4E15D0 4B2454BF 6F4FADF0 34B10403 119CD8E3
+		B92FCC5B`))
+	Salt1 = util.Must(util.BytesFromHexString(`
+		4D11FB6B EC38F9D2 546BB0F6 1E4F1C99 A1BC0DB8 F0D5F35B 1291B37B
+		213123D7 ED48F3C6 794D495B`))
+	Salt2 = util.Must(util.BytesFromHexString(`
+		A1B181AA FE881886 80AE3286 0D60BB01`))
+	SRPB = util.Must(util.BytesFromHexString(`
+		9C52401A 6A8084EC 82F01C37 25D3FB44 8BD2F0C9 09F9D977 26EAC4B7
+		A74172D9 52F02466 BE6734FA 274D2B74 29E27397 F10372D6 6B400B80
+		A5C5AE3F 28B17BF3 105D7A2D 2A885998 CDC2DEFC 208AEC21 7AB58859
+		A9ABC237 4AD93DC2 85F4B3FB CAFF4143 D7888F24 25BD2FB7 11B25609
+		CEB21757 D935B1EF 2F042173 AD0CE2FE 0E474DAC 53914BD2 5A8A9AED
+		4AEA8953 D55CB886 21DB37B8 71EA0D04 393AC098 7F68094C CC9DE823
+		9251375D 8FFFD263 316CD528 C097B7BC 9FB919FB EDB76C52 5DF3413C
+		374EE076 D97A1E6D 352BB7CC 80FD1365 1B04B32E 2E48C526 8150842C
+		FD07CF85 5958B1B5 EA9C36FD AD697FE3 AEC8DCC6 B1EFEC36 874AF226
+		204676CF`))
+	PrivateA = util.Must(util.BytesFromHexString(`
+		D6FA6430 DEC1FF2D 42DA3C8E B89EFC7C D49D63AD 02587E5B A4C2CAF9
+		7EAA09DE 2E1A4B65 68B46B9F 31E69CA2 7B639D43 9ACA3CBB 2BB3AA26
+		C7C974CB E84CA22D 9CBB1D0B ACD64170 C7E3A456 87B3206F 88CF286D
+		AC9AE1B9 653FDBC2 5B1901AD B18D59DC 2003768A 2B8311E7 7FE999D8
+		BE909A29 2A744B3A 8F5E908D 65A7FBD8 8D5CA04F 2653F915 A6DE92DE
+		127B3035 A660F236 B6AAC979 123616D2 76AFB98A 6739AC60 A90F0C53
+		3DCE1BDD A5C6E45E 93996CBA BDAAE5A5 575AAC52 14B63FE5 48F200F6
+		772AC86F 03A85820 C43EBA61 1B1446E1 1677F047 567F5586 9F1CD58F
+		2F8BE384 5C2125CD 4D800A60 25CEE4F2 C24B9C64 A5D16D39 5CC84E47
+		B9E98507`))
+	XA = util.Must(util.BytesFromHexString(`
+		1D34F0B7 771D27CD E864F7D9 92CBCCD8 BE6E0B0F 69D3AFC6 DEFC9D50
+		98BE5388 80F5BC1F EF4943D0 BDBAF513 C7536928 EA3D7342 6B9C5998
+		87B4151D 4F5F9A46 6C2B88DB 5EED0669 478C9024 FF9EE97C DCB66C97
+		7746EC18 1DC3B4F2 9C978D1D E6AEBB81 1A1B7E0D FB7EC3B6 2145AE4F
+		DA45CDA4 2ADE90E3 16C27F63 D269E92F 1C782345 57FF7705 2B5591D2
+		D14AFCDC 79904B92 59D96DF8 0A26663E 6B33D7DC 7BA07E29 64D00719
+		0F79DBA0 17BE0C2A 046CAD3E 53626FE7 127267A5 B7D2D2BF 06AE2CBF
+		62EB1DA8 2EF9989F B817728D 3A75A537 EFA99D49 B990C092 EB622CCD
+		948AAAE1 8619FCB6 D3B369C5 2E49905A F3C6C7E3 C23A7D12 B0EE73ED
+		2C24D614`))
+	M1 = util.Must(util.BytesFromHexString(`
+		B3E8BF66 F697F3E5 119C850B 507BA6D5 7D7EA14F B1A9FE81 6E57ACDB
+		BE4C51EC`))
+)
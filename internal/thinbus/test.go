@@ -0,0 +1,64 @@
+// Package thinbus provides test vectors for the Thinbus SRP-6a library using
+// the RFC 5054 2048-bit group and SHA-256. Apart from K, which is the
+// multiplier published in the Thinbus configuration for this group, none of
+// the values come from Thinbus itself as its test suite could not be
+// obtained. They were computed with an independent implementation of the
+// Thinbus JavaScript client so they do not prove interoperability. They should
+// be replaced by values from the Thinbus tests, or a real Thinbus session,
+// when they are available. The inputs were chosen so the salt,
+// H(I | ":" | P) and M1 all have leading zeros. Values are hex strings as sent
+// by Thinbus.
+//
+//nolint:gochecknoglobals
+package thinbus
+
+// Thinbus SRP-6a Test Vectors.
+var (
+	Identity = "tom@arcot.com"
+	Password = "password5"
+	Salt     = "001c3d34cadd9598a1025ac9f8ccdd6b95310ddf99f3b2b4ea6a822b03db625b"
+	K        = "5b9e8ef059c6b32ea59fc1d322d37f04aa30bae5aa9003b8321e21ddb04e300"
+	X        = "16e7af3a0fee9cb5459b0ee8e5d9cba6cefa4e4f61c2673b48e4a755aa00355f"
+	V        = "" +
+		"a0d378925131006eab0a2c313ea41ab9e7ffccdca79dfdb7143a67a357de940d" +
+		"b783506deb53b979b21b308e47234d09ef35ca2d0c0bd2a7faaa22fe4d3ac843" +
+		"59fba057418af2d8031d5ec7eeef131fd61ba484e317d4ad6fb9856187851380" +
+		"aa9f80942a27f816dafc35782eb5c27169afe8f32492892a62729baf88a2fc3e" +
+		"1f4ecd291e55dd962611b2f738d9204102a5cc52d6ee6ed87a9ca17ddcd98a75" +
+		"64b256364fcac7ddad8e49226ffc0a6c7e89402a8d0f639893b2d82c8ffb7960" +
+		"deca61a9f482248383a3674100bad5674063069be1de2db21bfb7ded1178f2ed" +
+		"0f2b602e9cb67749379bf2f3c2108e103cc29242ebd626a9eab0b4a7558bc4fe"
+	PrivateA = "308922ba8d30dd84aeb01f8e199655685788bbdfbcb862ec47150eb79e5e780b"
+	PrivateB = "6a01ba908473a871fe96ed22fb01d7a6fa4d0e47758bb9ff238a273fc687c26c"
+	XA       = "" +
+		"99f447a4642107281091d0598ee50d8aaf0874f827b93f5a0c3e3d629b9b14a0" +
+		"3118124bb551e8a39a045587fb26bf5296d20d84087fba0add741e967052a204" +
+		"c149080e432d216c6c8a790e78b3496777844b2a3e3552dbcbb0dccf3e09cac1" +
+		"94f03965511cdaf6adda17550772d70249ca8b37beec547a3b51b73eb14ec95b" +
+		"27ed2f4b9d1dc57ebb4b001ae50bdcdc4c72d4c4b2e8ed13206636049b6a0a61" +
+		"61484e2e37c47d4d839a70a71d8414b78709d494671e01e149f2ca0ad26fd47a" +
+		"d183a0a6037780aadda37f69b8f62d6e6dc5a2b9316bdc5b3bf0d3543b52461d" +
+		"cdfb21e0441d2827459e5fa9b49d5108dacc70989712034a4c5ecb35dbaac999"
+	XB = "" +
+		"6d8a7b8469f7e2571c47c2b1e1feef9945ae9652707a591b89bb98bdb04a6625" +
+		"ca93d20209dcfa412e6e479b5ce258636789abaf43047b5cbdb489fe5f13fe13" +
+		"fd699cca4b1ccc0a176eff72cb6c4f2026c8616fee38807630e7ae51a61b8284" +
+		"5c00ecc558aeccff88802f6276c7ce8fd539fa225e94c6e3f231dd4bb8a67e58" +
+		"8b4c8267df1febec7de6086efda669ab5b564851d089aaba336dd81ce98cb919" +
+		"348534a64751b7874e4771a322a55bb314db8856077f951579cec18bd8c812ef" +
+		"ba49e396615bc4c4d6b4b996515e141bd37e93684ac0329d541164b2e13096f1" +
+		"a7652693965750f4502023cb0dcbf525d6bdcc367d629c8e4dda2dde2e4ef93b"
+	U               = "9deac0b2e553e5be1ea800c9287acca5677b5e16edf4ce6b2194e7ae1dd6d186"
+	PremasterSecret = "" +
+		"8c3fb8270fa6996aa1ef0dfda3e10b034697905a48da5c8ee7ea0a78cf0354ae" +
+		"b78657547fc141ca3e51a77b76e81955405f73e1118a51db0890672490d591a2" +
+		"62793da6f300aa74d4dc7b5bba201488e4a728cfb485f054e4469160f84b6341" +
+		"ccbf18307f61457ad17b803c96ab97d7773869ae4f1cee323a54abb24ca660eb" +
+		"2f7855fcfa33b8b63894904e2a01394e2f6569c2994313b6eb95227f163c4183" +
+		"e651f707b46e82d03d5123b4f235f740fc3b99d366dfdeebb51e6c50a371d84a" +
+		"7469aa00cddee5bb34adad7cfbf9febe2761583368e51c42ebdd2fc921a0424c" +
+		"838df2aa8e643ce4cad68e65049ee28f24bc126db1472b382a001201ae325f9d"
+	SessionKey = "7455347556a6b5c2c8bbcd036834af487f758c2b8348f05f29671e4c933843c2"
+	M1         = "6f333b0b9517e09004d4f3d68337cb165a70abfd3d6b082d012086b91d52c4c"
+	M2         = "74ec6aba7e93cb093eceed5f7e1ab0939bfa3986deb66cfe866470f70154421b"
+)
@@ -1,8 +1,10 @@
 package util
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"math/big"
 	"regexp"
 )
@@ -35,3 +37,9 @@ func Pad(x *big.Int, n int) []byte {
 
 	return b
 }
+
+// FixedRand returns an io.Reader that reads b padded to n bytes, for use as a
+// predictable source of randomness when testing against known vectors.
+func FixedRand(b []byte, n int) io.Reader {
+	return bytes.NewReader(Pad(new(big.Int).SetBytes(b), n))
+}
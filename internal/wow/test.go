@@ -0,0 +1,50 @@
+// Package wow provides SRP test vectors for the World of Warcraft logon
+// protocol. No captured exchange or published vectors were available so they
+// were computed with an independent implementation of the TrinityCore SRP6
+// code. They should be replaced by a captured exchange, or the vectors from
+// the TrinityCore tests, when one is available. The private value b was chosen
+// so the premaster secret has a zero low byte to exercise the session key.
+// Numbers are little-endian apart from PrivateA and PrivateB which hold the
+// random bytes read for a and b.
+//
+//nolint:gochecknoglobals
+package wow
+
+import "github.com/bodgit/srp/internal/util"
+
+// WoW SRP Test Vectors.
+var (
+	Identity = []byte("alice")
+	Password = []byte("password123")
+	Salt     = util.Must(util.BytesFromHexString(`
+		3CEAD456 FC60B104 FF50A221 29FBC296 90D3F2BF E152EE82 933D06F5
+		AE2EE486`))
+	V = util.Must(util.BytesFromHexString(`
+		DBF8F9B5 B453AA0D 5DC924A8 42220906 86CEC665 ED61AE35 1EBEF787
+		C2968C02`))
+	PrivateA = util.Must(util.BytesFromHexString(`
+		43F294D5 CFC57051 CDD3B613 C6331501 4E971976 B4AD873F 0E46753E
+		AFA642DF`))
+	PrivateB = util.Must(util.BytesFromHexString(`
+		88DB8AEE E60A1437 78BBACE8 D618F1E9 E14895CF F2AE2F86 6859418F
+		E43B7AB6`))
+	XA = util.Must(util.BytesFromHexString(`
+		72577C29 76D356E4 E7D9A197 13F7F4C7 A8F54B63 CE018217 0451200B
+		21310A63`))
+	XB = util.Must(util.BytesFromHexString(`
+		BF4B05D9 9D82C978 43265961 82EC729B 8A43D89A 6A828B5B CB9BC372
+		3AD4B778`))
+	U = util.Must(util.BytesFromHexString(`
+		5BFA8CC2 160F8C6A B2C5A326 B02C04F4 A03999D0 00000000 00000000
+		00000000`))
+	PremasterSecret = util.Must(util.BytesFromHexString(`
+		0014A7DF 61872CFE EFD94BCC 4BD2A36E 6EC11C38 0A76342E 64CF9E24
+		7204A516`))
+	K = util.Must(util.BytesFromHexString(`
+		631A908E 051185F4 DB718CE0 E8BFA651 CA0EFB14 23EADD94 906D5F86
+		83987322 AF9DA133 F52A14E7`))
+	M1 = util.Must(util.BytesFromHexString(`
+		9CCCAC03 1D6FD521 DA43333C 710C880A C524AE92`))
+	M2 = util.Must(util.BytesFromHexString(`
+		3C9F0B4B BDE6ADEA A87405B9 38FCBF7C A11013D3`))
+)
@@ -2,22 +2,71 @@ package srp
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"io"
 )
 
 // ISV holds the triplet of the Identity, Salt, and Verifier. It implements
 // encoding.BinaryMarshaler and encoding.BinaryUnmarshaler so it can be
 // serialized to and from persistent storage.
+//
+// If Params is set then the ISV is serialized in a versioned format that also
+// records the group, hash and X function used to create it, otherwise the
+// original unversioned format is used. The byte order is only recorded, using
+// a newer version, if it is not BigEndian.
 type ISV struct {
-	Identity []byte `json:"identity"`
-	Salt     []byte `json:"salt"`
-	Verifier []byte `json:"verifier"`
+	Identity []byte  `json:"identity"`
+	Salt     []byte  `json:"salt"`
+	Verifier []byte  `json:"verifier"`
+	Params   *Params `json:"params,omitempty"`
+}
+
+const (
+	isvVersion2 = 2
+	isvVersion3 = 3
+)
+
+var (
+	// ErrUnknownVersion means the serialized ISV uses an unknown version.
+	ErrUnknownVersion = errors.New("unknown version")
+
+	// ErrNoParams means the ISV does not record its parameters.
+	ErrNoParams = errors.New("no parameters")
+)
+
+// isvMarker prefixes versioned ISVs. It would otherwise be read as an
+// identity of the maximum length so an unversioned ISV with such an identity
+// cannot be read.
+//
+//nolint:gochecknoglobals
+var isvMarker = []byte{0xff, 0xff}
+
+// NewSRPFromISV returns a new SRP using the parameters recorded in the ISV
+// along with any options. If the parameters use KDFCustom then the matching X
+// option must be passed.
+func NewSRPFromISV(i *ISV, options ...func(*SRP) error) (*SRP, error) {
+	if i.Params == nil {
+		return nil, ErrNoParams
+	}
+
+	return NewSRPFromParams(i.Params, options...)
 }
 
 // MarshalBinary satisfies the encoding.BinaryMarshaler interface.
 func (i *ISV) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
 
+	if i.Params != nil {
+		_, _ = b.Write(isvMarker)
+
+		if i.Params.Order != BigEndian {
+			_ = b.WriteByte(isvVersion3)
+		} else {
+			_ = b.WriteByte(isvVersion2)
+		}
+	}
+
 	if err := writeBytes(b, i.Identity); err != nil {
 		return nil, err
 	}
@@ -30,6 +79,16 @@ func (i *ISV) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 
+	if i.Params != nil {
+		if err := i.Params.write(b); err != nil {
+			return nil, err
+		}
+
+		if i.Params.Order != BigEndian {
+			_ = b.WriteByte(byte(i.Params.Order))
+		}
+	}
+
 	return b.Bytes(), nil
 }
 
@@ -37,6 +96,20 @@ func (i *ISV) MarshalBinary() ([]byte, error) {
 func (i *ISV) UnmarshalBinary(b []byte) (err error) {
 	r := bytes.NewReader(b)
 
+	var version byte = 1
+
+	if bytes.HasPrefix(b, isvMarker) {
+		_, _ = r.Seek(int64(len(isvMarker)), io.SeekStart)
+
+		if version, err = r.ReadByte(); err != nil {
+			return fmt.Errorf("unable to read version: %w", err)
+		}
+
+		if version != isvVersion2 && version != isvVersion3 {
+			return ErrUnknownVersion
+		}
+	}
+
 	if i.Identity, err = readBytes(r); err != nil {
 		return
 	}
@@ -49,6 +122,21 @@ func (i *ISV) UnmarshalBinary(b []byte) (err error) {
 		return
 	}
 
+	i.Params = nil
+
+	if version >= isvVersion2 {
+		i.Params = new(Params)
+		if err = i.Params.read(r); err != nil {
+			return
+		}
+	}
+
+	if version == isvVersion3 {
+		if err = i.Params.readOrder(r); err != nil {
+			return
+		}
+	}
+
 	if n, _ := io.CopyN(io.Discard, r, 1); n > 0 {
 		return ErrTrailingBytes
 	}
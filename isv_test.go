@@ -1,11 +1,15 @@
 package srp_test
 
 import (
+	"crypto"
 	"io"
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -146,3 +150,124 @@ func TestISV_UnmarshalBinary(t *testing.T) {
 		}
 	}
 }
+
+func TestISV_MarshalBinaryVersion2(t *testing.T) {
+	t.Parallel()
+
+	explicit := util.Must(srp.NewGroup(2, 3072, rfc5054.Hex3072))
+
+	tables := []struct {
+		group *srp.Group
+		x     func(*srp.SRP, []byte, []byte, []byte) *big.Int
+	}{
+		{
+			util.Must(srp.GetGroup(1024)),
+			nil,
+		},
+		{
+			explicit,
+			nil,
+		},
+		{
+			util.Must(srp.GetGroup(1024)),
+			func(s *srp.SRP, _, password, _ []byte) *big.Int {
+				return s.HashInt(password)
+			},
+		},
+	}
+
+	for _, table := range tables {
+		var options []func(*srp.SRP) error
+		if table.x != nil {
+			options = append(options, srp.X(table.x))
+		}
+
+		s := util.Must(srp.NewSRP(crypto.SHA256, table.group, options...))
+		i := util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))
+
+		b, err := i.MarshalBinary()
+		require.NoError(t, err)
+
+		newISV := new(srp.ISV)
+		require.NoError(t, newISV.UnmarshalBinary(b))
+		assert.Equal(t, i, newISV)
+
+		_, err = srp.NewSRPFromISV(newISV)
+		if table.x != nil {
+			require.ErrorIs(t, err, srp.ErrKDFMismatch)
+		} else {
+			require.NoError(t, err)
+		}
+
+		newSRP, err := srp.NewSRPFromISV(newISV, options...)
+		require.NoError(t, err)
+
+		client := util.Must(newSRP.NewClient(rfc5054.Identity, rfc5054.Password))
+		server := util.Must(newSRP.NewServer(newISV, client.A()))
+
+		m1, err := client.Compute(server.Salt(), server.B())
+		require.NoError(t, err)
+
+		_, err = server.Check(m1)
+		assert.NoError(t, err)
+	}
+}
+
+func TestISV_UnmarshalBinaryVersion2(t *testing.T) {
+	t.Parallel()
+
+	b := util.Must((&srp.ISV{
+		Identity: []byte{0x01},
+		Salt:     []byte{0x02},
+		Verifier: []byte{0x03},
+		Params:   util.Must(newSRP().Params()),
+	}).MarshalBinary())
+
+	tables := []struct {
+		b   []byte
+		err error
+	}{
+		{
+			b[:2],
+			io.EOF,
+		},
+		{
+			[]byte{0xff, 0xff, 0x04},
+			srp.ErrUnknownVersion,
+		},
+		{
+			b[:len(b)-1],
+			io.ErrUnexpectedEOF,
+		},
+		{
+			append(b, 0x00),
+			srp.ErrTrailingBytes,
+		},
+		{
+			b,
+			nil,
+		},
+	}
+
+	for _, table := range tables {
+		i := new(srp.ISV)
+
+		assert.ErrorIs(t, i.UnmarshalBinary(table.b), table.err)
+	}
+}
+
+func TestNewSRPFromISV(t *testing.T) {
+	t.Parallel()
+
+	_, err := srp.NewSRPFromISV(&srp.ISV{})
+	assert.ErrorIs(t, err, srp.ErrNoParams)
+
+	_, err = srp.NewSRPFromISV(&srp.ISV{
+		Params: &srp.Params{
+			Group: util.Must(srp.GetGroup(1024)),
+			Hash:  crypto.Hash(0),
+			KDF:   srp.KDFDefault,
+		},
+	})
+	assert.ErrorIs(t, err, srp.ErrHashUnavailable)
+}
@@ -0,0 +1,344 @@
+package srp
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KDF computes the X value from the identity, password and salt. Any cost
+// parameters are serialized with MarshalBinary so they can be recorded in an
+// ISV and sent to the client along with the salt.
+type KDF interface {
+	encoding.BinaryMarshaler
+
+	// ID returns the identifier recorded in an ISV.
+	ID() KDFID
+
+	// X computes the X value.
+	X(s *SRP, identity, password, salt []byte) (*big.Int, error)
+}
+
+const (
+	// KDFPBKDF2 identifies the PBKDF2 function.
+	KDFPBKDF2 KDFID = iota + KDFDefault + 1
+
+	// KDFScrypt identifies the Scrypt function.
+	KDFScrypt
+
+	// KDFArgon2id identifies the Argon2id function.
+	KDFArgon2id
+)
+
+// Limits on the KDF parameters. These stop a client that recreates a KDF sent
+// by the server with NewKDF from using whatever resources the server asks
+// for.
+const (
+	// MaxKDFKeyLen is the maximum key length in bytes.
+	MaxKDFKeyLen = 1024
+
+	// MaxPBKDF2Iterations is the maximum number of PBKDF2 iterations.
+	MaxPBKDF2Iterations = 10000000
+
+	// MaxScryptN is the maximum Scrypt CPU/memory cost.
+	MaxScryptN = 1 << 20
+
+	// MaxScryptR is the maximum Scrypt block size.
+	MaxScryptR = 32
+
+	// MaxScryptP is the maximum Scrypt parallelization.
+	MaxScryptP = 16
+
+	// MaxArgon2idTime is the maximum number of Argon2id passes.
+	MaxArgon2idTime = 64
+
+	// MaxArgon2idMemory is the maximum Argon2id memory in KiB.
+	MaxArgon2idMemory = 4 << 20
+)
+
+var (
+	// ErrUnknownKDF means the X function cannot be created from its
+	// identifier.
+	ErrUnknownKDF = errors.New("unknown X function")
+
+	// ErrInvalidKDFParams means the X function parameters are invalid.
+	ErrInvalidKDFParams = errors.New("invalid X function parameters")
+
+	errNilKDF = errors.New("X function must not be nil")
+)
+
+// NewKDF returns the KDF identified by id with its parameters decoded from
+// params. This is used by the client to recreate the KDF sent by the server.
+// KDFCustom cannot be recreated and returns ErrUnknownKDF.
+func NewKDF(id KDFID, params []byte) (KDF, error) { //nolint:ireturn
+	var k interface {
+		KDF
+		encoding.BinaryUnmarshaler
+	}
+
+	switch id {
+	case KDFCustom:
+		return nil, ErrUnknownKDF
+	case KDFDefault:
+		k = new(defaultKDF)
+	case KDFPBKDF2:
+		k = new(PBKDF2)
+	case KDFScrypt:
+		k = new(Scrypt)
+	case KDFArgon2id:
+		k = new(Argon2id)
+	default:
+		return nil, ErrUnknownKDF
+	}
+
+	if err := k.UnmarshalBinary(params); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// UseKDF overrides the default function for computing the X value with k.
+func UseKDF(k KDF) func(*SRP) error {
+	return func(s *SRP) error {
+		if k == nil {
+			return errNilKDF
+		}
+
+		s.kdf = k
+
+		return nil
+	}
+}
+
+// SetKDF overrides the default function for computing the X value with k.
+func (s *SRP) SetKDF(k KDF) error {
+	return s.setOption(UseKDF(k))
+}
+
+type defaultKDF struct{}
+
+func (defaultKDF) ID() KDFID {
+	return KDFDefault
+}
+
+func (defaultKDF) X(s *SRP, identity, password, salt []byte) (*big.Int, error) {
+	// x = H(s | H(I | ":" | P))
+	return s.HashInt(salt, s.HashBytes(identity, []byte(":"), password)), nil
+}
+
+func (defaultKDF) MarshalBinary() ([]byte, error) {
+	return nil, nil
+}
+
+func (defaultKDF) UnmarshalBinary(b []byte) error {
+	if len(b) > 0 {
+		return ErrTrailingBytes
+	}
+
+	return nil
+}
+
+type funcKDF func(*SRP, []byte, []byte, []byte) *big.Int
+
+func (funcKDF) ID() KDFID {
+	return KDFCustom
+}
+
+func (f funcKDF) X(s *SRP, identity, password, salt []byte) (*big.Int, error) {
+	return f(s, identity, password, salt), nil
+}
+
+func (funcKDF) MarshalBinary() ([]byte, error) {
+	return nil, nil
+}
+
+// PBKDF2 computes x = H(s | PBKDF2(I | ":" | P, s)) using HMAC with the hash
+// in use. Iterations must be between 1 and MaxPBKDF2Iterations.
+type PBKDF2 struct {
+	Iterations uint32
+	KeyLen     uint32
+}
+
+// ID returns KDFPBKDF2.
+func (k *PBKDF2) ID() KDFID {
+	return KDFPBKDF2
+}
+
+// X computes the X value.
+func (k *PBKDF2) X(s *SRP, identity, password, salt []byte) (*big.Int, error) {
+	if err := k.validate(); err != nil {
+		return nil, err
+	}
+
+	key := pbkdf2.Key(kdfPassword(identity, password), salt, int(k.Iterations), int(k.KeyLen), s.h.New)
+
+	return s.HashInt(salt, key), nil
+}
+
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface.
+func (k *PBKDF2) MarshalBinary() ([]byte, error) {
+	return marshalKDF(k.Iterations, k.KeyLen)
+}
+
+// UnmarshalBinary satisfies the encoding.BinaryUnmarshaler interface.
+func (k *PBKDF2) UnmarshalBinary(b []byte) error {
+	if err := unmarshalKDF(b, &k.Iterations, &k.KeyLen); err != nil {
+		return err
+	}
+
+	return k.validate()
+}
+
+func (k *PBKDF2) validate() error {
+	if k.Iterations < 1 || k.Iterations > MaxPBKDF2Iterations || !validKeyLen(k.KeyLen) {
+		return ErrInvalidKDFParams
+	}
+
+	return nil
+}
+
+// Scrypt computes x = H(s | Scrypt(I | ":" | P, s)). N must be a power of two
+// greater than one and no more than MaxScryptN, R and P must be between 1 and
+// MaxScryptR and MaxScryptP respectively.
+type Scrypt struct {
+	N      uint32
+	R      uint32
+	P      uint32
+	KeyLen uint32
+}
+
+// ID returns KDFScrypt.
+func (k *Scrypt) ID() KDFID {
+	return KDFScrypt
+}
+
+// X computes the X value.
+func (k *Scrypt) X(s *SRP, identity, password, salt []byte) (*big.Int, error) {
+	if err := k.validate(); err != nil {
+		return nil, err
+	}
+
+	key, err := scrypt.Key(kdfPassword(identity, password), salt, int(k.N), int(k.R), int(k.P), int(k.KeyLen))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKDFParams, err.Error())
+	}
+
+	return s.HashInt(salt, key), nil
+}
+
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface.
+func (k *Scrypt) MarshalBinary() ([]byte, error) {
+	return marshalKDF(k.N, k.R, k.P, k.KeyLen)
+}
+
+// UnmarshalBinary satisfies the encoding.BinaryUnmarshaler interface.
+func (k *Scrypt) UnmarshalBinary(b []byte) error {
+	if err := unmarshalKDF(b, &k.N, &k.R, &k.P, &k.KeyLen); err != nil {
+		return err
+	}
+
+	return k.validate()
+}
+
+func (k *Scrypt) validate() error {
+	if k.N < 2 || k.N > MaxScryptN || k.N&(k.N-1) != 0 ||
+		k.R < 1 || k.R > MaxScryptR || k.P < 1 || k.P > MaxScryptP || !validKeyLen(k.KeyLen) {
+		return ErrInvalidKDFParams
+	}
+
+	return nil
+}
+
+// Argon2id computes x = H(s | Argon2id(I | ":" | P, s)). Memory is measured
+// in KiB and must be no more than MaxArgon2idMemory, Time must be between 1
+// and MaxArgon2idTime and Threads must be at least 1.
+type Argon2id struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	KeyLen  uint32
+}
+
+// ID returns KDFArgon2id.
+func (k *Argon2id) ID() KDFID {
+	return KDFArgon2id
+}
+
+// X computes the X value.
+func (k *Argon2id) X(s *SRP, identity, password, salt []byte) (*big.Int, error) {
+	if err := k.validate(); err != nil {
+		return nil, err
+	}
+
+	key := argon2.IDKey(kdfPassword(identity, password), salt, k.Time, k.Memory, k.Threads, k.KeyLen)
+
+	return s.HashInt(salt, key), nil
+}
+
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface.
+func (k *Argon2id) MarshalBinary() ([]byte, error) {
+	return marshalKDF(k.Time, k.Memory, k.Threads, k.KeyLen)
+}
+
+// UnmarshalBinary satisfies the encoding.BinaryUnmarshaler interface.
+func (k *Argon2id) UnmarshalBinary(b []byte) error {
+	if err := unmarshalKDF(b, &k.Time, &k.Memory, &k.Threads, &k.KeyLen); err != nil {
+		return err
+	}
+
+	return k.validate()
+}
+
+func (k *Argon2id) validate() error {
+	if k.Time < 1 || k.Time > MaxArgon2idTime || k.Memory > MaxArgon2idMemory || k.Threads < 1 || !validKeyLen(k.KeyLen) {
+		return ErrInvalidKDFParams
+	}
+
+	return nil
+}
+
+func validKeyLen(n uint32) bool {
+	return n >= 1 && n <= MaxKDFKeyLen
+}
+
+func kdfPassword(identity, password []byte) []byte {
+	b := make([]byte, 0, len(identity)+1+len(password))
+
+	return append(append(append(b, identity...), ':'), password...)
+}
+
+func marshalKDF(params ...interface{}) ([]byte, error) {
+	b := new(bytes.Buffer)
+
+	for _, p := range params {
+		if err := binary.Write(b, binary.BigEndian, p); err != nil {
+			return nil, fmt.Errorf("unable to write parameter: %w", err)
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+func unmarshalKDF(b []byte, params ...interface{}) error {
+	r := bytes.NewReader(b)
+
+	for _, p := range params {
+		if err := binary.Read(r, binary.BigEndian, p); err != nil {
+			return fmt.Errorf("unable to read parameter: %w", err)
+		}
+	}
+
+	if r.Len() > 0 {
+		return ErrTrailingBytes
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package srp_test
+
+import (
+	"crypto"
+	"io"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKDF_X(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		kdf  srp.KDF
+		want []byte
+	}{
+		{
+			&srp.PBKDF2{Iterations: 1000, KeyLen: 32},
+			util.Must(util.BytesFromHexString(`
+				5A045A70 497E405F BBB4CCDE 039243D5 810C4168`)),
+		},
+		{
+			&srp.Scrypt{N: 1024, R: 8, P: 1, KeyLen: 32},
+			util.Must(util.BytesFromHexString(`
+				05D9B476 8ACE9C10 67CD89FC 9E16B329 02F70499`)),
+		},
+	}
+
+	for _, table := range tables {
+		x, err := table.kdf.X(newSRP(), rfc5054.Identity, rfc5054.Password, rfc5054.Salt)
+		require.NoError(t, err)
+		assert.Equal(t, table.want, x.Bytes())
+	}
+}
+
+func TestKDF_XInvalid(t *testing.T) {
+	t.Parallel()
+
+	tables := []srp.KDF{
+		&srp.PBKDF2{},
+		&srp.PBKDF2{Iterations: srp.MaxPBKDF2Iterations + 1, KeyLen: 32},
+		&srp.PBKDF2{Iterations: 1000, KeyLen: srp.MaxKDFKeyLen + 1},
+		&srp.Scrypt{N: 3, R: 8, P: 1, KeyLen: 32},
+		&srp.Scrypt{N: srp.MaxScryptN << 1, R: 8, P: 1, KeyLen: 32},
+		&srp.Scrypt{N: 1024, R: srp.MaxScryptR + 1, P: 1, KeyLen: 32},
+		&srp.Scrypt{N: 1024, R: 8, P: srp.MaxScryptP + 1, KeyLen: 32},
+		&srp.Argon2id{},
+		&srp.Argon2id{Time: srp.MaxArgon2idTime + 1, Memory: 1024, Threads: 1, KeyLen: 32},
+		&srp.Argon2id{Time: 1, Memory: srp.MaxArgon2idMemory + 1, Threads: 1, KeyLen: 32},
+	}
+
+	for _, table := range tables {
+		_, err := table.X(newSRP(), rfc5054.Identity, rfc5054.Password, rfc5054.Salt)
+		assert.ErrorIs(t, err, srp.ErrInvalidKDFParams)
+	}
+}
+
+func TestNewKDF(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		id     srp.KDFID
+		params []byte
+		err    error
+	}{
+		{
+			srp.KDFCustom,
+			nil,
+			srp.ErrUnknownKDF,
+		},
+		{
+			srp.KDFDefault,
+			nil,
+			nil,
+		},
+		{
+			srp.KDFDefault,
+			[]byte{0x00},
+			srp.ErrTrailingBytes,
+		},
+		{
+			srp.KDFPBKDF2,
+			[]byte{0x00, 0x00, 0x03, 0xe8, 0x00, 0x00, 0x00, 0x20},
+			nil,
+		},
+		{
+			srp.KDFPBKDF2,
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x20},
+			srp.ErrInvalidKDFParams,
+		},
+		{
+			srp.KDFPBKDF2,
+			[]byte{0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x20},
+			srp.ErrInvalidKDFParams,
+		},
+		{
+			srp.KDFScrypt,
+			[]byte{0x00},
+			io.ErrUnexpectedEOF,
+		},
+		{
+			srp.KDFScrypt,
+			[]byte{0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x20},
+			srp.ErrInvalidKDFParams,
+		},
+		{
+			srp.KDFArgon2id,
+			[]byte{0x00, 0x00, 0x00, 0x01, 0xff, 0xff, 0xff, 0xff, 0x01, 0x00, 0x00, 0x00, 0x20},
+			srp.ErrInvalidKDFParams,
+		},
+		{
+			srp.KDFID(0xff),
+			nil,
+			srp.ErrUnknownKDF,
+		},
+	}
+
+	for _, table := range tables {
+		_, err := srp.NewKDF(table.id, table.params)
+		assert.ErrorIs(t, err, table.err)
+	}
+}
+
+func TestUseKDFNil(t *testing.T) {
+	t.Parallel()
+
+	_, err := srp.NewSRP(crypto.SHA256, util.Must(srp.GetGroup(2048)), srp.UseKDF(nil))
+	assert.Error(t, err)
+}
+
+func TestKDFHandshake(t *testing.T) {
+	t.Parallel()
+
+	tables := []srp.KDF{
+		&srp.PBKDF2{Iterations: 1000, KeyLen: 32},
+		&srp.Scrypt{N: 1024, R: 8, P: 1, KeyLen: 32},
+		&srp.Argon2id{Time: 1, Memory: 1024, Threads: 1, KeyLen: 32},
+	}
+
+	for _, table := range tables {
+		s := util.Must(srp.NewSRP(crypto.SHA256, util.Must(srp.GetGroup(2048)), srp.UseKDF(table)))
+
+		b := util.Must(util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password)).MarshalBinary())
+
+		i := new(srp.ISV)
+		require.NoError(t, i.UnmarshalBinary(b))
+		assert.Equal(t, table.ID(), i.Params.KDF)
+
+		// The client only knows the group and hash in advance
+		c := util.Must(srp.NewSRP(crypto.SHA256, util.Must(srp.GetGroup(2048))))
+
+		client := util.Must(c.NewClient(rfc5054.Identity, rfc5054.Password))
+		server := util.Must(util.Must(srp.NewSRPFromISV(i)).NewServer(i, client.A()))
+
+		client.SetKDF(util.Must(srp.NewKDF(i.Params.KDF, i.Params.KDFParams)))
+
+		m1, err := client.Compute(server.Salt(), server.B())
+		require.NoError(t, err)
+
+		m2, err := server.Check(m1)
+		require.NoError(t, err)
+		require.NoError(t, client.Check(m2))
+	}
+}
@@ -0,0 +1,51 @@
+package srp
+
+import (
+	"crypto"
+	"math/big"
+)
+
+// NewSRP3 returns a new SRP implementing the original SRP-3 protocol as
+// documented in RFC 2945 using the chosen hash and group along with any
+// options. The multiplier is fixed at 1, U is taken from the first 32 bits of
+// H(B), and the session key is computed with the SHA_Interleave function.
+//
+// Warning: SRP-3 is vulnerable to a two-for-one guessing attack by an active
+// attacker impersonating the server and should only be used to interoperate
+// with legacy peers that cannot be upgraded to SRP-6a.
+func NewSRP3(hash crypto.Hash, group *Group, options ...func(*SRP) error) (*SRP, error) {
+	return NewSRP(hash, group, append([]func(*SRP) error{
+		K(MultiplierSRP3),
+		U(ComputeUSRP3),
+		SessionKey(Interleave),
+	}, options...)...)
+}
+
+// NewSRP6 returns a new SRP implementing the original SRP-6 protocol using
+// the chosen hash and group along with any options. It differs from SRP-6a
+// by using a fixed multiplier of 3.
+//
+// Warning: Using a fixed multiplier allows an attacker impersonating the
+// server to make two password guesses per session and should only be used to
+// interoperate with legacy peers that cannot be upgraded to SRP-6a.
+func NewSRP6(hash crypto.Hash, group *Group, options ...func(*SRP) error) (*SRP, error) {
+	return NewSRP(hash, group, append([]func(*SRP) error{
+		K(MultiplierSRP6),
+	}, options...)...)
+}
+
+// MultiplierSRP3 returns the SRP-3 multiplier, which is always 1.
+func MultiplierSRP3(_ *SRP) *big.Int {
+	return big.NewInt(1)
+}
+
+// MultiplierSRP6 returns the SRP-6 multiplier, which is always 3.
+func MultiplierSRP6(_ *SRP) *big.Int {
+	return big.NewInt(3)
+}
+
+// ComputeUSRP3 calculates the U value according to SRP-3 which uses the
+// first 32 bits of H(B).
+func ComputeUSRP3(s *SRP, _, xB *big.Int) *big.Int {
+	return s.Decode(s.HashBytes(s.Encode(xB))[:4])
+}
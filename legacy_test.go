@@ -0,0 +1,84 @@
+package srp_test
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/legacy"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen
+func TestLegacy(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		name                  string
+		srp                   func(crypto.Hash, *srp.Group, ...func(*srp.SRP) error) (*srp.SRP, error)
+		xB, u, xS, xK, m1, m2 []byte
+	}{
+		{
+			"SRP-3",
+			srp.NewSRP3,
+			legacy.SRP3XB,
+			legacy.SRP3U,
+			legacy.SRP3PremasterSecret,
+			legacy.SRP3K,
+			legacy.SRP3M1,
+			legacy.SRP3M2,
+		},
+		{
+			"SRP-6",
+			srp.NewSRP6,
+			legacy.SRP6XB,
+			legacy.SRP6U,
+			legacy.SRP6PremasterSecret,
+			legacy.SRP6K,
+			legacy.SRP6M1,
+			legacy.SRP6M2,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := util.Must(srp.GetGroup(1024))
+
+			client := util.Must(util.Must(table.srp(crypto.SHA1, g, srp.Rand(util.FixedRand(rfc5054.A, g.Size)))).NewClient(
+				rfc5054.Identity, rfc5054.Password))
+
+			assert.Equal(t, rfc5054.XA, client.A())
+
+			m1, err := client.Compute(rfc5054.Salt, table.xB)
+			require.NoError(t, err)
+			assert.Equal(t, table.m1, m1)
+
+			assert.Equal(t, table.u, util.Must(client.U()))
+			assert.Equal(t, table.xS, util.Must(client.S()))
+			assert.Equal(t, table.xK, client.Key())
+
+			server := util.Must(util.Must(table.srp(crypto.SHA1, g, srp.Rand(util.FixedRand(rfc5054.B, g.Size)))).NewServer(
+				&srp.ISV{
+					Identity: rfc5054.Identity,
+					Salt:     rfc5054.Salt,
+					Verifier: rfc5054.V,
+				}, rfc5054.XA))
+
+			assert.Equal(t, table.xB, server.B())
+
+			m2, err := server.Check(m1)
+			require.NoError(t, err)
+			assert.Equal(t, table.m2, m2)
+			assert.Equal(t, table.xK, server.Key())
+
+			assert.NoError(t, client.Check(m2))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Package nimbus implements the SRP-6a profile used by the default routines
+// of the Nimbus SRP6 library for Java, so that a Client or Server from this
+// module can authenticate against a Nimbus peer.
+//
+// Nimbus computes x = H(s | H(P)) without the identity and computes the
+// proofs from the premaster secret rather than the session key, hashing the
+// unpadded values. The multiplier and U value are computed the same way as
+// RFC 5054. The session key matches the session key hash returned by Nimbus.
+//
+// If the Nimbus peer is configured with XRoutineWithUserIdentity then the
+// default X function should be restored by passing srp.UseKDF with the KDF
+// returned by srp.NewKDF(srp.KDFDefault, nil) as an option.
+package nimbus
+
+import (
+	"crypto"
+	"math/big"
+
+	"github.com/bodgit/srp"
+)
+
+// NewSRP returns a new srp.SRP struct using the chosen hash and group along
+// with any additional options.
+func NewSRP(hash crypto.Hash, group *srp.Group, options ...func(*srp.SRP) error) (*srp.SRP, error) {
+	//nolint:wrapcheck
+	return srp.NewSRP(hash, group, append([]func(*srp.SRP) error{
+		srp.X(ComputeX),
+		srp.M1(ComputeM1),
+		srp.M2(ComputeM2),
+	}, options...)...)
+}
+
+// ComputeX calculates the X value according to Nimbus which ignores the
+// identity.
+func ComputeX(s *srp.SRP, _, password, salt []byte) *big.Int {
+	// x = H(s | H(P))
+	return s.HashInt(salt, s.HashBytes(password))
+}
+
+// ComputeM1 calculates the M1 proof according to Nimbus.
+func ComputeM1(s *srp.SRP, xA, xB, xS *big.Int, _, _, _ []byte) []byte {
+	// M1 = H(A | B | S)
+	return s.HashBytes(s.Encode(xA), s.Encode(xB), s.Encode(xS))
+}
+
+// ComputeM2 calculates the M2 proof according to Nimbus.
+func ComputeM2(s *srp.SRP, xA, xS *big.Int, m1, _ []byte) []byte {
+	// M2 = H(A | M1 | S)
+	return s.HashBytes(s.Encode(xA), m1, s.Encode(xS))
+}
@@ -0,0 +1,67 @@
+package nimbus_test
+
+import (
+	"bytes"
+	"crypto"
+	"testing"
+
+	"github.com/bodgit/srp"
+	vectors "github.com/bodgit/srp/internal/nimbus"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/bodgit/srp/nimbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	s, err := nimbus.NewSRP(crypto.SHA1, util.Must(srp.GetGroup(1024)), srp.Rand(bytes.NewReader(rfc5054.Salt)),
+		srp.SaltSize(len(rfc5054.Salt)))
+	require.NoError(t, err)
+
+	i, err := s.NewISV(rfc5054.Identity, rfc5054.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, rfc5054.Salt, i.Salt)
+	assert.Equal(t, vectors.V, i.Verifier)
+
+	require.NoError(t, s.SetRand(util.FixedRand(rfc5054.A, 128)))
+
+	client, err := s.NewClient(rfc5054.Identity, rfc5054.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, rfc5054.XA, client.A())
+
+	require.NoError(t, s.SetRand(util.FixedRand(rfc5054.B, 128)))
+
+	server, err := s.NewServer(i, client.A())
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.XB, server.B())
+
+	m1, err := client.Compute(server.Salt(), server.B())
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.U, util.Must(client.U()))
+	assert.Equal(t, vectors.PremasterSecret, util.Must(client.S()))
+	assert.Equal(t, vectors.K, client.Key())
+	assert.Equal(t, vectors.M1, m1)
+
+	m2, err := server.Check(m1)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.M2, m2)
+	assert.NoError(t, client.Check(m2))
+}
+
+func TestComputeX(t *testing.T) {
+	t.Parallel()
+
+	s := util.Must(nimbus.NewSRP(crypto.SHA1, util.Must(srp.GetGroup(1024))))
+
+	// The identity is ignored
+	assert.Equal(t, vectors.X, nimbus.ComputeX(s, nil, rfc5054.Password, rfc5054.Salt).Bytes())
+	assert.Equal(t, vectors.X, nimbus.ComputeX(s, rfc5054.Identity, rfc5054.Password, rfc5054.Salt).Bytes())
+}
@@ -0,0 +1,142 @@
+package srp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+
+	"github.com/bodgit/srp/internal/util"
+)
+
+// ByteOrder is the order in which big numbers are encoded as bytes.
+type ByteOrder uint8
+
+const (
+	// BigEndian encodes big numbers most significant byte first without
+	// any padding. This is the default.
+	BigEndian ByteOrder = iota
+
+	// LittleEndian encodes big numbers least significant byte first. As
+	// the protocols that use it are fixed width, numbers are always padded
+	// to the size of the group.
+	LittleEndian
+)
+
+// ErrUnknownByteOrder means the byte order is not BigEndian or LittleEndian.
+var ErrUnknownByteOrder = errors.New("unknown byte order")
+
+// Order overrides the default BigEndian byte order used to encode and decode
+// the public values, the premaster secret, the verifier and the inputs to the
+// default hash computations.
+func Order(o ByteOrder) func(*SRP) error {
+	return func(s *SRP) error {
+		if o > LittleEndian {
+			return ErrUnknownByteOrder
+		}
+
+		s.order = o
+
+		return nil
+	}
+}
+
+// SetOrder overrides the default BigEndian byte order used to encode and
+// decode the public values, the premaster secret, the verifier and the inputs
+// to the default hash computations.
+func (s *SRP) SetOrder(o ByteOrder) error {
+	return s.setOption(Order(o))
+}
+
+// Encode returns x as a byte slice using the byte order in use.
+func (s *SRP) Encode(x *big.Int) []byte {
+	return s.codec().encode(x)
+}
+
+// Decode returns the byte slice b as a big.Int using the byte order in use.
+func (s *SRP) Decode(b []byte) *big.Int {
+	return s.codec().decode(b)
+}
+
+func (s *SRP) codec() codec {
+	c := codec{order: s.order}
+
+	// The size is only needed to pad little-endian numbers
+	if c.order == LittleEndian {
+		c.size = s.Group().Size
+	}
+
+	return c
+}
+
+// pad returns x as a byte slice padded to the size of the group, which is
+// used for inputs to the default hash computations.
+func (s *SRP) pad(x *big.Int) []byte {
+	if s.order == LittleEndian {
+		return s.Encode(x)
+	}
+
+	return util.Pad(x, s.Group().Size)
+}
+
+// codec holds what is needed to encode and decode big numbers so that a
+// Server can do so without a reference to its SRP.
+type codec struct {
+	order ByteOrder
+	size  int
+}
+
+func (c codec) write(w io.Writer) error {
+	if c.size > math.MaxUint16 {
+		return ErrTooBig
+	}
+
+	//nolint:gosec
+	if err := binary.Write(w, binary.BigEndian, []uint16{uint16(c.order), uint16(c.size)}); err != nil {
+		return fmt.Errorf("unable to write byte order: %w", err)
+	}
+
+	return nil
+}
+
+func (c *codec) read(r io.Reader) error {
+	v := make([]uint16, 2)
+	if err := binary.Read(r, binary.BigEndian, v); err != nil {
+		return fmt.Errorf("unable to read byte order: %w", err)
+	}
+
+	if ByteOrder(v[0]) != LittleEndian || v[1] == 0 {
+		return ErrUnknownByteOrder
+	}
+
+	c.order, c.size = ByteOrder(v[0]), int(v[1])
+
+	return nil
+}
+
+func (c codec) encode(x *big.Int) []byte {
+	if c.order == LittleEndian {
+		return reverse(util.Pad(x, c.size))
+	}
+
+	return x.Bytes()
+}
+
+func (c codec) decode(b []byte) *big.Int {
+	if c.order == LittleEndian {
+		return new(big.Int).SetBytes(reverse(b))
+	}
+
+	return new(big.Int).SetBytes(b)
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i, c := range b {
+		r[len(b)-1-i] = c
+	}
+
+	return r
+}
@@ -0,0 +1,129 @@
+package srp_test
+
+import (
+	"crypto"
+	"math/big"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLittleEndian() *srp.SRP {
+	return util.Must(srp.NewSRP(crypto.SHA256, util.Must(srp.GetGroup(1024)), srp.Order(srp.LittleEndian)))
+}
+
+func TestOrder(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		order srp.ByteOrder
+		x     *big.Int
+		want  []byte
+		err   error
+	}{
+		{
+			srp.BigEndian,
+			big.NewInt(0x0102),
+			[]byte{0x01, 0x02},
+			nil,
+		},
+		{
+			srp.LittleEndian,
+			big.NewInt(0x0102),
+			append([]byte{0x02, 0x01}, make([]byte, 126)...),
+			nil,
+		},
+		{
+			srp.LittleEndian + 1,
+			nil,
+			nil,
+			srp.ErrUnknownByteOrder,
+		},
+	}
+
+	for _, table := range tables {
+		s, err := srp.NewSRP(crypto.SHA256, util.Must(srp.GetGroup(1024)), srp.Order(table.order))
+		if table.err != nil {
+			assert.ErrorIs(t, err, table.err)
+
+			continue
+		}
+
+		require.NoError(t, err)
+		assert.Equal(t, table.want, s.Encode(table.x))
+		assert.Equal(t, table.x, s.Decode(table.want))
+	}
+}
+
+func TestLittleEndianHandshake(t *testing.T) {
+	t.Parallel()
+
+	s := newLittleEndian()
+
+	testHandshake(t, s)
+
+	client := util.Must(s.NewClient(rfc5054.Identity, rfc5054.Password))
+
+	assert.Len(t, client.A(), s.Group().Size)
+}
+
+func TestLittleEndianServer_UnmarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	s := newLittleEndian()
+	client := util.Must(s.NewClient(rfc5054.Identity, rfc5054.Password))
+	server := util.Must(s.NewServer(util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password)), client.A()))
+
+	newServer := new(srp.Server)
+	require.NoError(t, newServer.UnmarshalBinary(util.Must(server.MarshalBinary())))
+
+	assert.Equal(t, server, newServer)
+	assert.Equal(t, server.B(), newServer.B())
+}
+
+func TestLittleEndianISV(t *testing.T) {
+	t.Parallel()
+
+	i := util.Must(newLittleEndian().NewISV(rfc5054.Identity, rfc5054.Password))
+
+	assert.Equal(t, srp.LittleEndian, i.Params.Order)
+
+	b, err := i.MarshalBinary()
+	require.NoError(t, err)
+
+	// The marker is followed by the version
+	assert.Equal(t, byte(3), b[2])
+
+	newISV := new(srp.ISV)
+	require.NoError(t, newISV.UnmarshalBinary(b))
+	assert.Equal(t, i, newISV)
+
+	s, err := srp.NewSRPFromISV(newISV)
+	require.NoError(t, err)
+
+	testHandshake(t, s)
+
+	pb, err := i.Params.MarshalBinary()
+	require.NoError(t, err)
+
+	// The marker is followed by the version
+	assert.Equal(t, []byte{0xff, 0x02}, pb[:2])
+
+	params := new(srp.Params)
+	require.NoError(t, params.UnmarshalBinary(pb))
+	assert.True(t, i.Params.Equal(params))
+
+	pb[1] = 0x03
+	assert.ErrorIs(t, params.UnmarshalBinary(pb), srp.ErrUnknownVersion)
+
+	// The byte order is not guessed from a trailing byte
+	pb = append(util.Must(util.Must(newSRP().Params()).MarshalBinary()), byte(srp.LittleEndian))
+	assert.ErrorIs(t, params.UnmarshalBinary(pb), srp.ErrTrailingBytes)
+
+	b[len(b)-1] = 0x02
+	assert.ErrorIs(t, newISV.UnmarshalBinary(b), srp.ErrUnknownByteOrder)
+}
@@ -0,0 +1,332 @@
+package srp
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+)
+
+// KDFID identifies the function used to compute the X value.
+type KDFID uint8
+
+const (
+	// KDFCustom identifies a function set with the X option. It cannot be
+	// recreated from its identifier alone so the same X option must be
+	// passed again when recreating the SRP.
+	KDFCustom KDFID = iota
+
+	// KDFDefault identifies the default function, x = H(s | H(I | ":" | P)).
+	KDFDefault
+)
+
+// Params records the parameters that affect the verifier so that a matching
+// SRP can be recreated later. If the byte order is not BigEndian then Params
+// is serialized in a versioned format that records it, otherwise the original
+// unversioned format is used.
+type Params struct {
+	Group     *Group      `json:"group"`
+	Hash      crypto.Hash `json:"hash"`
+	KDF       KDFID       `json:"kdf"`
+	KDFParams []byte      `json:"kdfParams,omitempty"`
+	Order     ByteOrder   `json:"order,omitempty"`
+}
+
+const (
+	// paramsMarker prefixes versioned Params. It is never used as a group
+	// identifier so the original format cannot start with it.
+	paramsMarker   = 0xff
+	paramsVersion2 = 2
+)
+
+var (
+	// ErrHashUnavailable means the hash function is not linked into the
+	// binary.
+	ErrHashUnavailable = errors.New("hash function unavailable")
+
+	// ErrKDFMismatch means the X function of an SRP does not match the
+	// recorded parameters.
+	ErrKDFMismatch = errors.New("X function does not match parameters")
+
+	// ErrInvalidGroup means a group recorded in full is malformed.
+	ErrInvalidGroup = errors.New("invalid group")
+)
+
+// NewSRPFromParams returns a new SRP using the recorded parameters along with
+// any options. If the parameters use KDFCustom then the matching X option
+// must be passed.
+func NewSRPFromParams(p *Params, options ...func(*SRP) error) (*SRP, error) {
+	if !p.Hash.Available() {
+		return nil, ErrHashUnavailable
+	}
+
+	defaults := []func(*SRP) error{Order(p.Order)}
+
+	if p.KDF != KDFCustom {
+		kdf, err := NewKDF(p.KDF, p.KDFParams)
+		if err != nil {
+			return nil, err
+		}
+
+		defaults = append(defaults, UseKDF(kdf))
+	}
+
+	s, err := NewSRP(p.Hash, p.Group, append(defaults, options...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.kdf.ID() != p.KDF {
+		return nil, ErrKDFMismatch
+	}
+
+	return s, nil
+}
+
+// Params returns the parameters that affect the verifier.
+func (s *SRP) Params() (*Params, error) {
+	b, err := s.kdf.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal X function parameters: %w", err)
+	}
+
+	if len(b) == 0 {
+		b = nil
+	}
+
+	return &Params{
+		Group:     s.Group(),
+		Hash:      s.h,
+		KDF:       s.kdf.ID(),
+		KDFParams: b,
+		Order:     s.order,
+	}, nil
+}
+
+// WithParams returns a copy of s using the group, hash, X function and byte
+// order recorded in p while keeping any other options. If the parameters use
+// KDFCustom then s must also use a custom X function which is assumed to
+// match.
+func (s *SRP) WithParams(p *Params) (*SRP, error) {
+	if !p.Hash.Available() {
+		return nil, ErrHashUnavailable
+	}
+
+	kdf := s.kdf
+
+	if p.KDF != KDFCustom {
+		var err error
+		if kdf, err = NewKDF(p.KDF, p.KDFParams); err != nil {
+			return nil, err
+		}
+	} else if kdf.ID() != KDFCustom {
+		return nil, ErrKDFMismatch
+	}
+
+	if p.Order > LittleEndian {
+		return nil, ErrUnknownByteOrder
+	}
+
+	n := *s
+	n.h, n.g, n.kdf, n.order = p.Hash, p.Group, kdf, p.Order
+
+	return &n, nil
+}
+
+// Equal reports whether p and o record the same parameters.
+func (p *Params) Equal(o *Params) bool {
+	if p == nil || o == nil {
+		return p == o
+	}
+
+	return p.Group.equal(o.Group) && p.Hash == o.Hash && p.KDF == o.KDF && bytes.Equal(p.KDFParams, o.KDFParams) &&
+		p.Order == o.Order
+}
+
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface.
+func (p *Params) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+
+	if p.Order != BigEndian {
+		_, _ = b.Write([]byte{paramsMarker, paramsVersion2})
+	}
+
+	if err := p.write(b); err != nil {
+		return nil, err
+	}
+
+	if p.Order != BigEndian {
+		_ = b.WriteByte(byte(p.Order))
+	}
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalBinary satisfies the encoding.BinaryUnmarshaler interface.
+func (p *Params) UnmarshalBinary(b []byte) error {
+	r := bytes.NewReader(b)
+
+	versioned := len(b) > 0 && b[0] == paramsMarker
+	if versioned {
+		_, _ = r.Seek(1, io.SeekStart)
+
+		version, err := r.ReadByte()
+		if err != nil {
+			return fmt.Errorf("unable to read version: %w", err)
+		}
+
+		if version != paramsVersion2 {
+			return ErrUnknownVersion
+		}
+	}
+
+	if err := p.read(r); err != nil {
+		return err
+	}
+
+	if versioned {
+		if err := p.readOrder(r); err != nil {
+			return err
+		}
+	}
+
+	if r.Len() > 0 {
+		return ErrTrailingBytes
+	}
+
+	return nil
+}
+
+func (p *Params) write(w io.Writer) error {
+	if p.Hash > math.MaxUint8 {
+		return ErrHashUnavailable
+	}
+
+	id := groupID(p.Group)
+
+	if err := binary.Write(w, binary.BigEndian, id); err != nil {
+		return fmt.Errorf("unable to write group: %w", err)
+	}
+
+	if id == 0 {
+		if err := writeBytes(w, p.Group.G.Bytes()); err != nil {
+			return err
+		}
+
+		if err := writeBytes(w, p.Group.N.Bytes()); err != nil {
+			return err
+		}
+
+		if p.Group.Size > math.MaxUint16 {
+			return ErrTooBig
+		}
+
+		//nolint:gosec
+		if err := binary.Write(w, binary.BigEndian, uint16(p.Group.Size)); err != nil {
+			return fmt.Errorf("unable to write group size: %w", err)
+		}
+	}
+
+	//nolint:gosec
+	if err := binary.Write(w, binary.BigEndian, []uint8{uint8(p.Hash), uint8(p.KDF)}); err != nil {
+		return fmt.Errorf("unable to write identifiers: %w", err)
+	}
+
+	return writeBytes(w, p.KDFParams)
+}
+
+func (p *Params) readOrder(r io.Reader) error {
+	var order ByteOrder
+	if err := binary.Read(r, binary.BigEndian, &order); err != nil {
+		return fmt.Errorf("unable to read byte order: %w", err)
+	}
+
+	if order != LittleEndian {
+		return ErrUnknownByteOrder
+	}
+
+	p.Order = order
+
+	return nil
+}
+
+// checkGroup rejects a decoded group that could not be used safely, without
+// the more expensive tests performed by Validate.
+func checkGroup(g *Group) error {
+	if g.N.Sign() <= 0 {
+		return fmt.Errorf("%w: N is zero", ErrInvalidGroup)
+	}
+
+	if size := (g.N.BitLen() + 7) >> 3; g.Size != size {
+		return fmt.Errorf("%w: N is %d bytes but size is %d", ErrInvalidGroup, size, g.Size)
+	}
+
+	if g.G.Cmp(big.NewInt(1)) <= 0 || g.G.Cmp(new(big.Int).Sub(g.N, big.NewInt(1))) >= 0 {
+		return fmt.Errorf("%w: g is not between 1 and N-1", ErrInvalidGroup)
+	}
+
+	return nil
+}
+
+func (p *Params) read(r io.Reader) error {
+	p.Order = BigEndian
+
+	var id uint8
+	if err := binary.Read(r, binary.BigEndian, &id); err != nil {
+		return fmt.Errorf("unable to read group: %w", err)
+	}
+
+	if id == 0 {
+		g, err := readBytes(r)
+		if err != nil {
+			return err
+		}
+
+		n, err := readBytes(r)
+		if err != nil {
+			return err
+		}
+
+		var size uint16
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return fmt.Errorf("unable to read group size: %w", err)
+		}
+
+		p.Group = &Group{
+			G:    new(big.Int).SetBytes(g),
+			N:    new(big.Int).SetBytes(n),
+			Size: int(size),
+		}
+
+		if err := checkGroup(p.Group); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if p.Group, err = groupByID(id); err != nil {
+			return err
+		}
+	}
+
+	ids := make([]uint8, 2)
+	if err := binary.Read(r, binary.BigEndian, ids); err != nil {
+		return fmt.Errorf("unable to read identifiers: %w", err)
+	}
+
+	p.Hash, p.KDF = crypto.Hash(ids[0]), KDFID(ids[1])
+
+	var err error
+	if p.KDFParams, err = readBytes(r); err != nil {
+		return err
+	}
+
+	if len(p.KDFParams) == 0 {
+		p.KDFParams = nil
+	}
+
+	return nil
+}
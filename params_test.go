@@ -0,0 +1,117 @@
+package srp_test
+
+import (
+	"crypto"
+	"math/big"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParams_Equal(t *testing.T) {
+	t.Parallel()
+
+	p := util.Must(newSRP().Params())
+
+	assert.True(t, p.Equal(util.Must(newSRP().Params())))
+	assert.False(t, p.Equal(util.Must(newTarget().Params())))
+	assert.False(t, p.Equal(nil))
+	assert.True(t, (*srp.Params)(nil).Equal(nil))
+}
+
+func TestParams_MarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	p := util.Must(newTarget().Params())
+
+	newParams := new(srp.Params)
+	require.NoError(t, newParams.UnmarshalBinary(util.Must(p.MarshalBinary())))
+	assert.Equal(t, p, newParams)
+
+	assert.ErrorIs(t, newParams.UnmarshalBinary(append(util.Must(p.MarshalBinary()), 0x00)), srp.ErrTrailingBytes)
+}
+
+func TestParams_UnmarshalBinaryGroup(t *testing.T) {
+	t.Parallel()
+
+	g := util.Must(srp.GetGroup(1024))
+
+	tables := []struct {
+		name  string
+		group *srp.Group
+		err   error
+	}{
+		{
+			name:  "custom",
+			group: &srp.Group{G: big.NewInt(7), N: g.N, Size: g.Size},
+		},
+		{
+			name:  "zero N",
+			group: &srp.Group{G: big.NewInt(2), N: new(big.Int), Size: g.Size},
+			err:   srp.ErrInvalidGroup,
+		},
+		{
+			name:  "zero size",
+			group: &srp.Group{G: g.G, N: g.N},
+			err:   srp.ErrInvalidGroup,
+		},
+		{
+			name:  "size mismatch",
+			group: &srp.Group{G: g.G, N: g.N, Size: g.Size + 1},
+			err:   srp.ErrInvalidGroup,
+		},
+		{
+			name:  "g is one",
+			group: &srp.Group{G: big.NewInt(1), N: g.N, Size: g.Size},
+			err:   srp.ErrInvalidGroup,
+		},
+		{
+			name:  "g is N-1",
+			group: &srp.Group{G: new(big.Int).Sub(g.N, big.NewInt(1)), N: g.N, Size: g.Size},
+			err:   srp.ErrInvalidGroup,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := (&srp.Params{Group: table.group, Hash: crypto.SHA1, KDF: srp.KDFDefault}).MarshalBinary()
+			require.NoError(t, err)
+
+			p := new(srp.Params)
+			err = p.UnmarshalBinary(b)
+
+			if table.err != nil {
+				assert.ErrorIs(t, err, table.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, table.group, p.Group)
+		})
+	}
+}
+
+func TestSRP_WithParams(t *testing.T) {
+	t.Parallel()
+
+	s := util.Must(newSRP().WithParams(util.Must(newTarget().Params())))
+	assert.Equal(t, util.Must(newTarget().Params()), util.Must(s.Params()))
+
+	custom := util.Must(srp.NewSRP(crypto.SHA1, util.Must(srp.GetGroup(1024)), srp.X(
+		func(*srp.SRP, []byte, []byte, []byte) *big.Int {
+			return big.NewInt(1)
+		})))
+
+	_, err := newSRP().WithParams(util.Must(custom.Params()))
+	require.ErrorIs(t, err, srp.ErrKDFMismatch)
+
+	_, err = custom.WithParams(util.Must(custom.Params()))
+	assert.NoError(t, err)
+}
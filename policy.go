@@ -0,0 +1,76 @@
+package srp
+
+import (
+	"crypto"
+	"errors"
+	"math/big"
+)
+
+// GroupPolicy decides which groups sent by a server a client will accept. As
+// per RFC 5054 section 3.2 a client must not use a group it does not know to
+// be safe, otherwise a malicious server can choose a weak group that lets it
+// recover the password offline. The standard groups returned by
+// GetGroupByName are always accepted.
+type GroupPolicy struct {
+	// Allowed lists any other groups to accept.
+	Allowed []*Group
+
+	// Validate accepts any other group that passes Group.Validate. The
+	// checks are probabilistic and slow for large groups so it is better
+	// to list the expected groups in Allowed.
+	Validate bool
+}
+
+// ErrUnknownGroup means a group sent by a server is not accepted by the
+// GroupPolicy.
+var ErrUnknownGroup = errors.New("unknown group")
+
+// Accept returns the Group with the generator g and prime N sent by a server
+// if it is accepted by p. If the group is only accepted after validating it
+// then the error from Group.Validate is returned if that fails, otherwise
+// ErrUnknownGroup is returned. A nil p only accepts the standard groups.
+func (p *GroupPolicy) Accept(g, n []byte) (*Group, error) {
+	xN := new(big.Int).SetBytes(n)
+
+	group := &Group{
+		G:    new(big.Int).SetBytes(g),
+		N:    xN,
+		Size: (xN.BitLen() + 7) >> 3,
+	}
+
+	if name, ok := LookupGroup(group.N, group.G); ok {
+		return namedGroups[name], nil
+	}
+
+	if p == nil {
+		return nil, ErrUnknownGroup
+	}
+
+	for _, allowed := range p.Allowed {
+		if allowed.G.Cmp(group.G) == 0 && allowed.N.Cmp(group.N) == 0 {
+			return allowed, nil
+		}
+	}
+
+	if !p.Validate {
+		return nil, ErrUnknownGroup
+	}
+
+	if err := group.Validate(); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// NewSRPFromServer returns a new SRP for a client using the chosen hash and
+// the group with the generator g and prime N sent by a server, along with any
+// options. It fails unless the group is accepted by the policy.
+func NewSRPFromServer(hash crypto.Hash, g, n []byte, policy *GroupPolicy, options ...func(*SRP) error) (*SRP, error) {
+	group, err := policy.Accept(g, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSRP(hash, group, options...)
+}
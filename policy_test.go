@@ -0,0 +1,95 @@
+package srp_test
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupPolicy(t *testing.T) {
+	t.Parallel()
+
+	rfc := util.Must(srp.GetGroup(2048))
+	safe := util.Must(srp.NewGroup(2, 1024, safePrime))
+	unsafe := util.Must(srp.NewGroup(2, 1024, unsafePrime))
+
+	tables := []struct {
+		name   string
+		policy *srp.GroupPolicy
+		group  *srp.Group
+		err    error
+	}{
+		{
+			"rfc5054",
+			nil,
+			rfc,
+			nil,
+		},
+		{
+			"rfc7919",
+			nil,
+			util.Must(srp.GetGroupByName("ffdhe2048")),
+			nil,
+		},
+		{
+			"unknown",
+			nil,
+			safe,
+			srp.ErrUnknownGroup,
+		},
+		{
+			"allowed",
+			&srp.GroupPolicy{
+				Allowed: []*srp.Group{safe},
+			},
+			safe,
+			nil,
+		},
+		{
+			"not allowed",
+			&srp.GroupPolicy{
+				Allowed: []*srp.Group{safe},
+			},
+			unsafe,
+			srp.ErrUnknownGroup,
+		},
+		{
+			"validated",
+			&srp.GroupPolicy{
+				Validate: true,
+			},
+			safe,
+			nil,
+		},
+		{
+			"not validated",
+			&srp.GroupPolicy{
+				Validate: true,
+			},
+			unsafe,
+			srp.ErrGroupNotSafePrime,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := srp.NewSRPFromServer(crypto.SHA256, table.group.G.Bytes(), table.group.N.Bytes(), table.policy)
+			if table.err != nil {
+				assert.ErrorIs(t, err, table.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, table.group, s.Group())
+		})
+	}
+}
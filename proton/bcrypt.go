@@ -0,0 +1,55 @@
+package proton
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/blowfish"
+)
+
+const (
+	bcryptCost     = 10
+	bcryptSaltSize = 16
+	bcryptHashSize = 23
+)
+
+// bcryptEncoding is the base64 alphabet used by bcrypt.
+//
+//nolint:gochecknoglobals
+var bcryptEncoding = base64.NewEncoding("./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789").
+	WithPadding(base64.NoPadding)
+
+// bcrypt returns the bcrypt hash of password in its modular crypt format
+// using the 16-byte salt. golang.org/x/crypto/bcrypt always generates a random
+// salt so the algorithm is repeated here using the same blowfish primitives.
+func bcrypt(password, salt []byte) ([]byte, error) {
+	if len(salt) != bcryptSaltSize {
+		return nil, ErrInvalidSalt
+	}
+
+	// Include the trailing NUL like the C implementations
+	key := append(append(make([]byte, 0, len(password)+1), password...), 0)
+
+	c, err := blowfish.NewSaltedCipher(key, salt)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cipher: %w", err)
+	}
+
+	for i := 0; i < 1<<bcryptCost; i++ {
+		blowfish.ExpandKey(key, c)
+		blowfish.ExpandKey(salt, c)
+	}
+
+	b := []byte("OrpheanBeholderScryDoubt")
+
+	for i := 0; i < len(b); i += blowfish.BlockSize {
+		for j := 0; j < 64; j++ {
+			c.Encrypt(b[i:i+blowfish.BlockSize], b[i:i+blowfish.BlockSize])
+		}
+	}
+
+	// Only 23 of the 24 encrypted bytes are encoded like the C
+	// implementations
+	return []byte(fmt.Sprintf("$2y$%02d$%s%s", bcryptCost,
+		bcryptEncoding.EncodeToString(salt), bcryptEncoding.EncodeToString(b[:bcryptHashSize]))), nil
+}
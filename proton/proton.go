@@ -0,0 +1,169 @@
+// Package proton implements the SRP profile used by Proton for auth version
+// 4. Numbers are encoded little-endian, hashes are expanded to the size of the
+// 2048-bit group by concatenating four SHA-512 digests and the X value is
+// derived from a bcrypt hash of the password.
+//
+// The modulus is sent by the server in a message signed with a key known to
+// Proton clients. Verifying the signature is left to the caller and only the
+// decoded modulus is passed to this package.
+package proton
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+
+	"github.com/bodgit/srp"
+)
+
+// Version is the auth version implemented by this package.
+const Version = 4
+
+const (
+	bits      = 2048
+	size      = bits >> 3
+	saltSize  = 10
+	generator = 2
+	rounds    = 10
+)
+
+var (
+	// ErrInvalidModulus means the modulus is not a 2048-bit safe prime for
+	// which 2 generates the whole group.
+	ErrInvalidModulus = errors.New("invalid modulus")
+
+	// ErrInvalidSalt means the salt is not 10 bytes.
+	ErrInvalidSalt = errors.New("invalid salt size")
+)
+
+// NewGroup returns a new srp.Group using the little-endian modulus sent by
+// the server after checking it is safe to use.
+func NewGroup(modulus []byte) (*srp.Group, error) {
+	if len(modulus) != size {
+		return nil, ErrInvalidModulus
+	}
+
+	n := new(big.Int).SetBytes(reverse(modulus))
+	if n.BitLen() != bits {
+		return nil, ErrInvalidModulus
+	}
+
+	// 2 must not be a square so that it generates the whole group, by
+	// quadratic reciprocity this along with N and (N-1)/2 being prime
+	// means N = 3 mod 8
+	if n.Bit(0) != 1 || n.Bit(1) != 1 || n.Bit(2) != 0 {
+		return nil, ErrInvalidModulus
+	}
+
+	g, q := big.NewInt(generator), new(big.Int).Rsh(n, 1)
+	if !q.ProbablyPrime(rounds) {
+		return nil, ErrInvalidModulus
+	}
+
+	// Lucas primality test for N, 2^((N-1)/2) = -1 mod N
+	if new(big.Int).Exp(g, q, n).Cmp(new(big.Int).Sub(n, big.NewInt(1))) != 0 {
+		return nil, ErrInvalidModulus
+	}
+
+	return &srp.Group{
+		G:    g,
+		N:    n,
+		Size: size,
+	}, nil
+}
+
+// NewSRP returns a new srp.SRP struct using the little-endian modulus sent by
+// the server along with any additional options. The identity is not used.
+func NewSRP(modulus []byte, options ...func(*srp.SRP) error) (*srp.SRP, error) {
+	group, err := NewGroup(modulus)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:wrapcheck
+	return srp.NewSRP(crypto.SHA512, group, append([]func(*srp.SRP) error{
+		srp.Order(srp.LittleEndian),
+		srp.UseKDF(KDF{}),
+		srp.SaltSize(saltSize),
+		srp.K(func(s *srp.SRP) *big.Int {
+			// k = H(g | N) % N
+			k := s.Decode(ExpandHash(s.Encode(s.Group().G), s.Encode(s.Group().N)))
+
+			return k.Mod(k, s.Group().N)
+		}),
+		srp.U(func(s *srp.SRP, xA, xB *big.Int) *big.Int {
+			// u = H(A | B)
+			return s.Decode(ExpandHash(s.Encode(xA), s.Encode(xB)))
+		}),
+		srp.SessionKey(func(s *srp.SRP, xS *big.Int) []byte {
+			// K = S
+			return s.Encode(xS)
+		}),
+		srp.M1(func(s *srp.SRP, xA, xB, xS *big.Int, _, _, _ []byte) []byte {
+			// M1 = H(A | B | S)
+			return ExpandHash(s.Encode(xA), s.Encode(xB), s.Encode(xS))
+		}),
+		srp.M2(func(s *srp.SRP, xA, xS *big.Int, m1, _ []byte) []byte {
+			// M2 = H(A | M1 | S)
+			return ExpandHash(s.Encode(xA), m1, s.Encode(xS))
+		}),
+	}, options...)...)
+}
+
+// ExpandHash hashes each passed byte slice and returns the concatenation of
+// the SHA-512 digests with each of the suffixes 0 to 3, which is the same size
+// as the group.
+func ExpandHash(a ...[]byte) []byte {
+	b := make([]byte, 0, 4*sha512.Size)
+
+	for i := byte(0); i < 4; i++ {
+		h := sha512.New()
+		for _, z := range a {
+			_, _ = h.Write(z)
+		}
+
+		_, _ = h.Write([]byte{i})
+		b = h.Sum(b)
+	}
+
+	return b
+}
+
+// KDF computes x = H(bcrypt(P, s | "proton") | N) where bcrypt uses a cost of
+// 10. It is used by NewSRP and the salt must be 10 bytes.
+type KDF struct{}
+
+// ID returns srp.KDFCustom as the X function cannot be recorded in an ISV. The
+// same modulus must be passed to NewSRP when recreating the SRP.
+func (KDF) ID() srp.KDFID {
+	return srp.KDFCustom
+}
+
+// X computes the X value.
+func (KDF) X(s *srp.SRP, _, password, salt []byte) (*big.Int, error) {
+	if len(salt) != saltSize {
+		return nil, ErrInvalidSalt
+	}
+
+	b, err := bcrypt(password, append(append(make([]byte, 0, bcryptSaltSize), salt...), "proton"...))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Decode(ExpandHash(b, s.Encode(s.Group().N))), nil
+}
+
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface.
+func (KDF) MarshalBinary() ([]byte, error) {
+	return nil, nil
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i, c := range b {
+		r[len(b)-1-i] = c
+	}
+
+	return r
+}
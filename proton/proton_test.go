@@ -0,0 +1,126 @@
+package proton_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bodgit/srp"
+	vectors "github.com/bodgit/srp/internal/proton"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/bodgit/srp/proton"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	s, err := proton.NewSRP(vectors.Modulus, srp.Rand(bytes.NewReader(vectors.PrivateA)))
+	require.NoError(t, err)
+
+	x, err := proton.KDF{}.X(s, nil, vectors.Password, vectors.Salt)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.X, s.Encode(x))
+
+	client, err := s.NewClient(nil, vectors.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.XA, client.A())
+
+	m1, err := client.Compute(vectors.Salt, vectors.XB)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.PremasterSecret, util.Must(client.S()))
+	assert.Equal(t, vectors.M1, m1)
+	assert.NoError(t, client.Check(vectors.M2))
+}
+
+func TestNewISV(t *testing.T) {
+	t.Parallel()
+
+	s, err := proton.NewSRP(vectors.Modulus, srp.Rand(bytes.NewReader(vectors.Salt)))
+	require.NoError(t, err)
+
+	i, err := s.NewISV(nil, vectors.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.Salt, i.Salt)
+	assert.Equal(t, vectors.V, i.Verifier)
+
+	require.NoError(t, s.SetRand(nil))
+
+	client, err := s.NewClient(nil, vectors.Password)
+	require.NoError(t, err)
+
+	server, err := s.NewServer(i, client.A())
+	require.NoError(t, err)
+
+	m1, err := client.Compute(server.Salt(), server.B())
+	require.NoError(t, err)
+
+	m2, err := server.Check(m1)
+	require.NoError(t, err)
+
+	assert.NoError(t, client.Check(m2))
+	assert.Equal(t, client.Key(), server.Key())
+}
+
+func TestNewGroup(t *testing.T) {
+	t.Parallel()
+
+	// Flipping bit 1 of N breaks N = 3 mod 8
+	mod8 := append([]byte{}, vectors.Modulus...)
+	mod8[0] ^= 0x02
+
+	// Changing a high byte keeps N = 3 mod 8 but N is no longer prime
+	composite := append([]byte{}, vectors.Modulus...)
+	composite[128] ^= 0x01
+
+	tables := []struct {
+		name    string
+		modulus []byte
+		err     error
+	}{
+		{
+			"valid",
+			vectors.Modulus,
+			nil,
+		},
+		{
+			"too short",
+			vectors.Modulus[:len(vectors.Modulus)-1],
+			proton.ErrInvalidModulus,
+		},
+		{
+			"not 3 mod 8",
+			mod8,
+			proton.ErrInvalidModulus,
+		},
+		{
+			"not prime",
+			composite,
+			proton.ErrInvalidModulus,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := proton.NewGroup(table.modulus)
+			assert.ErrorIs(t, err, table.err)
+		})
+	}
+}
+
+func TestKDF(t *testing.T) {
+	t.Parallel()
+
+	s := util.Must(proton.NewSRP(vectors.Modulus))
+
+	_, err := proton.KDF{}.X(s, nil, vectors.Password, vectors.Salt[1:])
+	assert.ErrorIs(t, err, proton.ErrInvalidSalt)
+}
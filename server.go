@@ -13,25 +13,40 @@ import (
 type Server struct {
 	xA, b, xB, xS    *big.Int
 	salt, xK, m1, m2 []byte
+	c                codec
 }
 
 // Reset resets s to its initial state using the passed parameters.
 func (s *Server) Reset(srp *SRP, i *ISV, xA []byte) error {
-	a := new(big.Int).SetBytes(xA)
+	a := srp.Decode(xA)
 	if new(big.Int).Mod(a, srp.Group().N).Sign() == 0 {
 		return ErrInvalidPublicKey
 	}
 
-	b, err := randBigInt(srp.Group().Size)
-	if err != nil {
+	v := srp.Decode(i.Verifier)
+
+	if err := s.init(srp, i, v); err != nil {
 		return err
 	}
 
-	v := new(big.Int).SetBytes(i.Verifier)
+	return s.compute(srp, i, v, a)
+}
+
+func (s *Server) init(srp *SRP, i *ISV, v *big.Int) error {
+	b, err := randBigInt(srp.rand, srp.Group().Size)
+	if err != nil {
+		return err
+	}
 
-	s.xA = a
 	s.b, s.xB = b, srp.computeB(b, srp.multiplier(), v)
 	s.salt = i.Salt
+	s.c = srp.codec()
+
+	return nil
+}
+
+func (s *Server) compute(srp *SRP, i *ISV, v, xA *big.Int) error {
+	s.xA = xA
 
 	u, err := srp.computeU(s.xA, s.xB)
 	if err != nil {
@@ -40,8 +55,8 @@ func (s *Server) Reset(srp *SRP, i *ISV, xA []byte) error {
 
 	s.xS = srp.computeServerS(s.xA, s.b, u, v)
 	s.xK = srp.computeK(s.xS)
-	s.m1 = srp.computeM1(s.xA, s.xB, s.xK, i.Identity, s.salt)
-	s.m2 = srp.computeM2(s.xA, s.m1, s.xK)
+	s.m1 = srp.computeM1(s.xA, s.xB, s.xS, s.xK, i.Identity, s.salt)
+	s.m2 = srp.computeM2(s.xA, s.xS, s.m1, s.xK)
 
 	return nil
 }
@@ -53,7 +68,13 @@ func (s *Server) Salt() []byte {
 
 // B returns the server public value.
 func (s *Server) B() []byte {
-	return s.xB.Bytes()
+	return s.c.encode(s.xB)
+}
+
+// S returns the premaster secret shared with the client. This is only needed
+// by protocols that derive further keys from it rather than using Key.
+func (s *Server) S() []byte {
+	return s.c.encode(s.xS)
 }
 
 // Check compares the M1 proof computed by the client with the servers copy.
@@ -108,6 +129,14 @@ func (s *Server) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 
+	// The byte order is only recorded if it is not the default so the
+	// original format is unchanged
+	if s.c.order != BigEndian {
+		if err := s.c.write(b); err != nil {
+			return nil, err
+		}
+	}
+
 	return b.Bytes(), nil
 }
 
@@ -159,6 +188,14 @@ func (s *Server) UnmarshalBinary(b []byte) error {
 		return err
 	}
 
+	s.c = codec{}
+
+	if r.Len() > 0 {
+		if err := s.c.read(r); err != nil {
+			return err
+		}
+	}
+
 	if n, _ := io.CopyN(io.Discard, r, 1); n > 0 {
 		return ErrTrailingBytes
 	}
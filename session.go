@@ -0,0 +1,101 @@
+package srp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// SessionStore is implemented by storage for the pending sessions of an
+// Authenticator. Implementations must be safe for concurrent use. The data is
+// sealed by the Authenticator so it does not need to be kept confidential.
+type SessionStore interface {
+	// Put stores the session data under the ID until it expires.
+	Put(ctx context.Context, id string, data []byte, expires time.Time) error
+
+	// Take removes and returns the session data for the ID, or returns
+	// ErrSessionNotFound if there is no session or it has expired. Only
+	// one caller can take a session.
+	Take(ctx context.Context, id string) ([]byte, error)
+
+	// Expire removes any sessions that expired before now.
+	Expire(ctx context.Context, now time.Time) error
+}
+
+// ErrSessionNotFound means the session does not exist or has expired.
+var ErrSessionNotFound = errors.New("session not found")
+
+type memorySession struct {
+	data    []byte
+	expires time.Time
+}
+
+// MemorySessionStore is a SessionStore that holds sessions in memory.
+type MemorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]memorySession
+}
+
+// NewMemorySessionStore returns a new, empty MemorySessionStore.
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		sessions: make(map[string]memorySession),
+	}
+}
+
+// Put stores the session data under the ID until it expires.
+func (m *MemorySessionStore) Put(_ context.Context, id string, data []byte, expires time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions[id] = memorySession{
+		data:    data,
+		expires: expires,
+	}
+
+	return nil
+}
+
+// Take removes and returns the session data for the ID, or returns
+// ErrSessionNotFound if there is no session or it has expired.
+func (m *MemorySessionStore) Take(_ context.Context, id string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+
+	delete(m.sessions, id)
+
+	if !time.Now().Before(session.expires) {
+		return nil, ErrSessionNotFound
+	}
+
+	return session.data, nil
+}
+
+// Expire removes any sessions that expired before now.
+func (m *MemorySessionStore) Expire(_ context.Context, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, session := range m.sessions {
+		if session.expires.Before(now) {
+			delete(m.sessions, id)
+		}
+	}
+
+	return nil
+}
+
+// Len returns the number of stored sessions, including any that have expired
+// but not yet been removed.
+func (m *MemorySessionStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.sessions)
+}
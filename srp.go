@@ -9,20 +9,25 @@ import (
 	"crypto"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
-
-	"github.com/bodgit/srp/internal/util"
 )
 
 // SRP manages the various computations used in the SRP protocol.
 type SRP struct {
-	h crypto.Hash
-	g *Group
-
-	x func(*SRP, []byte, []byte, []byte) *big.Int
-	k func(*SRP) *big.Int
-	u func(*SRP, *big.Int, *big.Int) *big.Int
+	h     crypto.Hash
+	g     *Group
+	rand  io.Reader
+	kdf   KDF
+	salt  int
+	order ByteOrder
+
+	k   func(*SRP) *big.Int
+	u   func(*SRP, *big.Int, *big.Int) *big.Int
+	key func(*SRP, *big.Int) []byte
+	m1  func(*SRP, *big.Int, *big.Int, *big.Int, []byte, []byte, []byte) []byte
+	m2  func(*SRP, *big.Int, *big.Int, []byte, []byte) []byte
 }
 
 var (
@@ -38,14 +43,16 @@ var (
 	ErrTooBig = fmt.Errorf("value exceeds %d bytes", math.MaxUint16)
 
 	errMismatchedProof = errors.New("mismatched proof")
+	errInvalidSaltSize = errors.New("salt size must be positive")
 )
 
 // NewSRP returns a new SRP using the chosen hash and group along with any
 // options.
 func NewSRP(hash crypto.Hash, group *Group, options ...func(*SRP) error) (*SRP, error) {
 	s := &SRP{
-		h: hash,
-		g: group,
+		h:   hash,
+		g:   group,
+		kdf: defaultKDF{},
 	}
 
 	if err := s.setOption(options...); err != nil {
@@ -66,14 +73,26 @@ func (s *SRP) HashBytes(a ...[]byte) []byte {
 	return h.Sum(nil)
 }
 
-// HashInt hashes each passed byte slice and returns the digest as a big.Int.
+// HashInt hashes each passed byte slice and returns the digest as a big.Int
+// decoded using the byte order in use.
 func (s *SRP) HashInt(a ...[]byte) *big.Int {
-	return new(big.Int).SetBytes(s.HashBytes(a...))
+	return s.Decode(s.HashBytes(a...))
 }
 
-// NewISV creates a new ISV containing the identity, salt and verifier.
+// NewISV creates a new ISV containing the identity, salt and verifier along
+// with the parameters used to create it.
 func (s *SRP) NewISV(identity, password []byte) (*ISV, error) {
-	salt, err := randBytes(s.Group().Size)
+	salt, err := randBytes(s.rand, s.saltSize())
+	if err != nil {
+		return nil, err
+	}
+
+	x, err := s.computeX(nil, identity, password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := s.Params()
 	if err != nil {
 		return nil, err
 	}
@@ -81,13 +100,14 @@ func (s *SRP) NewISV(identity, password []byte) (*ISV, error) {
 	return &ISV{
 		Identity: identity,
 		Salt:     salt,
-		Verifier: s.computeV(s.computeX(identity, password, salt)).Bytes(),
+		Verifier: s.Encode(s.computeV(x)),
+		Params:   params,
 	}, nil
 }
 
 // NewClient creates a new Client using the identity and password.
 func (s *SRP) NewClient(identity, password []byte) (*Client, error) {
-	a, err := randBigInt(s.Group().Size)
+	a, err := randBigInt(s.rand, s.Group().Size)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +145,7 @@ func (s *SRP) SetK(f func(*SRP) *big.Int) error {
 // X overrides the default function for computing the X value.
 func X(f func(*SRP, []byte, []byte, []byte) *big.Int) func(*SRP) error {
 	return func(s *SRP) error {
-		s.x = f
+		s.kdf = funcKDF(f)
 
 		return nil
 	}
@@ -150,6 +170,92 @@ func (s *SRP) SetU(f func(*SRP, *big.Int, *big.Int) *big.Int) error {
 	return s.setOption(U(f))
 }
 
+// SessionKey overrides the default function for computing the session key K
+// from the premaster secret S.
+func SessionKey(f func(*SRP, *big.Int) []byte) func(*SRP) error {
+	return func(s *SRP) error {
+		s.key = f
+
+		return nil
+	}
+}
+
+// SetSessionKey overrides the default function for computing the session key
+// K from the premaster secret S.
+func (s *SRP) SetSessionKey(f func(*SRP, *big.Int) []byte) error {
+	return s.setOption(SessionKey(f))
+}
+
+// M1 overrides the default function for computing the M1 proof. The function
+// is passed A, B, S, K, the identity and the salt.
+func M1(f func(*SRP, *big.Int, *big.Int, *big.Int, []byte, []byte, []byte) []byte) func(*SRP) error {
+	return func(s *SRP) error {
+		s.m1 = f
+
+		return nil
+	}
+}
+
+// SetM1 overrides the default function for computing the M1 proof. The
+// function is passed A, B, S, K, the identity and the salt.
+func (s *SRP) SetM1(f func(*SRP, *big.Int, *big.Int, *big.Int, []byte, []byte, []byte) []byte) error {
+	return s.setOption(M1(f))
+}
+
+// M2 overrides the default function for computing the M2 proof. The function
+// is passed A, S, M1 and K.
+func M2(f func(*SRP, *big.Int, *big.Int, []byte, []byte) []byte) func(*SRP) error {
+	return func(s *SRP) error {
+		s.m2 = f
+
+		return nil
+	}
+}
+
+// SetM2 overrides the default function for computing the M2 proof. The
+// function is passed A, S, M1 and K.
+func (s *SRP) SetM2(f func(*SRP, *big.Int, *big.Int, []byte, []byte) []byte) error {
+	return s.setOption(M2(f))
+}
+
+// Rand overrides the default source of randomness, crypto/rand.Reader, used
+// for generating salts and private values. It should only be used for
+// testing.
+func Rand(r io.Reader) func(*SRP) error {
+	return func(s *SRP) error {
+		s.rand = r
+
+		return nil
+	}
+}
+
+// SetRand overrides the default source of randomness, crypto/rand.Reader,
+// used for generating salts and private values. It should only be used for
+// testing.
+func (s *SRP) SetRand(r io.Reader) error {
+	return s.setOption(Rand(r))
+}
+
+// SaltSize overrides the size of the salt generated for a new ISV. The
+// default is the size of the group.
+func SaltSize(n int) func(*SRP) error {
+	return func(s *SRP) error {
+		if n < 1 {
+			return errInvalidSaltSize
+		}
+
+		s.salt = n
+
+		return nil
+	}
+}
+
+// SetSaltSize overrides the size of the salt generated for a new ISV. The
+// default is the size of the group.
+func (s *SRP) SetSaltSize(n int) error {
+	return s.setOption(SaltSize(n))
+}
+
 // Group returns the Group in use.
 func (s *SRP) Group() *Group {
 	return s.g
@@ -165,12 +271,20 @@ func (s *SRP) setOption(options ...func(*SRP) error) error {
 	return nil
 }
 
+func (s *SRP) saltSize() int {
+	if s.salt > 0 {
+		return s.salt
+	}
+
+	return s.Group().Size
+}
+
 func (s *SRP) multiplier() *big.Int {
 	if s.k != nil {
 		return s.k(s)
 	}
 
-	return s.HashInt(s.Group().N.Bytes(), util.Pad(s.Group().G, s.Group().Size))
+	return s.HashInt(s.Encode(s.Group().N), s.pad(s.Group().G))
 }
 
 func (s *SRP) computeA(a *big.Int) *big.Int {
@@ -186,13 +300,13 @@ func (s *SRP) computeB(b, k, v *big.Int) *big.Int {
 		s.Group().N)
 }
 
-func (s *SRP) computeX(identity, password, salt []byte) *big.Int {
-	if s.x != nil {
-		return s.x(s, identity, password, salt)
+func (s *SRP) computeX(kdf KDF, identity, password, salt []byte) (*big.Int, error) {
+	if kdf == nil {
+		kdf = s.kdf
 	}
 
-	// x = H(s | H(I | ":" | P))
-	return s.HashInt(salt, s.HashBytes(identity, []byte(":"), password))
+	//nolint:wrapcheck
+	return kdf.X(s, identity, password, salt)
 }
 
 func (s *SRP) computeV(x *big.Int) *big.Int {
@@ -206,7 +320,7 @@ func (s *SRP) computeU(xA, xB *big.Int) (*big.Int, error) {
 		u = s.u(s, xA, xB)
 	} else {
 		// u = H(A | B)
-		u = s.HashInt(util.Pad(xA, s.Group().Size), util.Pad(xB, s.Group().Size))
+		u = s.HashInt(s.pad(xA), s.pad(xB))
 	}
 
 	if u.Sign() == 0 {
@@ -230,18 +344,31 @@ func (s *SRP) computeServerS(xA, b, u, v *big.Int) *big.Int {
 }
 
 func (s *SRP) computeK(xS *big.Int) []byte {
-	return s.HashBytes(xS.Bytes())
+	if s.key != nil {
+		return s.key(s, xS)
+	}
+
+	// K = H(S)
+	return s.HashBytes(s.Encode(xS))
 }
 
-func (s *SRP) computeM1(xA, xB *big.Int, xK, identity, salt []byte) []byte {
+func (s *SRP) computeM1(xA, xB, xS *big.Int, xK, identity, salt []byte) []byte {
+	if s.m1 != nil {
+		return s.m1(s, xA, xB, xS, xK, identity, salt)
+	}
+
 	// M1 = H(H(N) XOR H(g) | H(U) | s | A | B | K)
 	xor := make([]byte, s.h.New().Size())
-	_ = xorBytes(xor, s.HashBytes(s.Group().N.Bytes()), s.HashBytes(s.Group().G.Bytes()))
+	_ = xorBytes(xor, s.HashBytes(s.Encode(s.Group().N)), s.HashBytes(s.Encode(s.Group().G)))
 
-	return s.HashBytes(xor, s.HashBytes(identity), salt, xA.Bytes(), xB.Bytes(), xK)
+	return s.HashBytes(xor, s.HashBytes(identity), salt, s.Encode(xA), s.Encode(xB), xK)
 }
 
-func (s *SRP) computeM2(xA *big.Int, m1, xK []byte) []byte {
+func (s *SRP) computeM2(xA, xS *big.Int, m1, xK []byte) []byte {
+	if s.m2 != nil {
+		return s.m2(s, xA, xS, m1, xK)
+	}
+
 	// M2 = H(A | M | K)
-	return s.HashBytes(xA.Bytes(), m1, xK)
+	return s.HashBytes(s.Encode(xA), m1, xK)
 }
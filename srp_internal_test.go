@@ -90,7 +90,12 @@ func TestSRP_computeX(t *testing.T) {
 			_ = s.SetX(table.kdf)
 		}
 
-		assert.Equal(t, table.want, s.computeX(table.identity, table.password, table.salt).Bytes())
+		x, err := s.computeX(nil, table.identity, table.password, table.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, table.want, x.Bytes())
 	}
 }
 
@@ -147,22 +152,119 @@ func TestSRP_computeServerS(t *testing.T) {
 		new(big.Int).SetBytes(rfc5054.V)).Bytes())
 }
 
+func TestSRP_computeK(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		key  func(*SRP, *big.Int) []byte
+		want []byte
+	}{
+		{
+			nil,
+			newSRP().HashBytes(rfc5054.PremasterSecret),
+		},
+		{
+			func(*SRP, *big.Int) []byte {
+				return []byte{0x01}
+			},
+			[]byte{0x01},
+		},
+	}
+
+	for _, table := range tables {
+		s := newSRP()
+
+		if table.key != nil {
+			_ = s.SetSessionKey(table.key)
+		}
+
+		assert.Equal(t, table.want, s.computeK(new(big.Int).SetBytes(rfc5054.PremasterSecret)))
+	}
+}
+
 func TestSRP_computeM1(t *testing.T) {
 	t.Parallel()
 
-	assert.Equal(t, rfc2945.M1, newSRP().computeM1(
-		new(big.Int).SetBytes(rfc5054.XA),
-		new(big.Int).SetBytes(rfc5054.XB),
-		newSRP().computeK(new(big.Int).SetBytes(rfc5054.PremasterSecret)),
-		rfc5054.Identity,
-		rfc5054.Salt))
+	tables := []struct {
+		m1   func(*SRP, *big.Int, *big.Int, *big.Int, []byte, []byte, []byte) []byte
+		want []byte
+	}{
+		{
+			nil,
+			rfc2945.M1,
+		},
+		{
+			func(s *SRP, xA, xB, xS *big.Int, _, _, _ []byte) []byte {
+				return s.HashBytes(xA.Bytes(), xB.Bytes(), xS.Bytes())
+			},
+			newSRP().HashBytes(rfc5054.XA, rfc5054.XB, rfc5054.PremasterSecret),
+		},
+	}
+
+	for _, table := range tables {
+		s := newSRP()
+
+		if table.m1 != nil {
+			_ = s.SetM1(table.m1)
+		}
+
+		assert.Equal(t, table.want, s.computeM1(
+			new(big.Int).SetBytes(rfc5054.XA),
+			new(big.Int).SetBytes(rfc5054.XB),
+			new(big.Int).SetBytes(rfc5054.PremasterSecret),
+			s.computeK(new(big.Int).SetBytes(rfc5054.PremasterSecret)),
+			rfc5054.Identity,
+			rfc5054.Salt))
+	}
 }
 
 func TestSRP_computeM2(t *testing.T) {
 	t.Parallel()
 
-	assert.Equal(t, rfc2945.M2, newSRP().computeM2(
-		new(big.Int).SetBytes(rfc5054.XA),
-		rfc2945.M1,
-		newSRP().computeK(new(big.Int).SetBytes(rfc5054.PremasterSecret))))
+	tables := []struct {
+		m2   func(*SRP, *big.Int, *big.Int, []byte, []byte) []byte
+		want []byte
+	}{
+		{
+			nil,
+			rfc2945.M2,
+		},
+		{
+			func(s *SRP, xA, xS *big.Int, m1, _ []byte) []byte {
+				return s.HashBytes(xA.Bytes(), m1, xS.Bytes())
+			},
+			newSRP().HashBytes(rfc5054.XA, rfc2945.M1, rfc5054.PremasterSecret),
+		},
+	}
+
+	for _, table := range tables {
+		s := newSRP()
+
+		if table.m2 != nil {
+			_ = s.SetM2(table.m2)
+		}
+
+		assert.Equal(t, table.want, s.computeM2(
+			new(big.Int).SetBytes(rfc5054.XA),
+			new(big.Int).SetBytes(rfc5054.PremasterSecret),
+			rfc2945.M1,
+			s.computeK(new(big.Int).SetBytes(rfc5054.PremasterSecret))))
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	t.Parallel()
+
+	s := util.Must(NewSRP(crypto.SHA1, util.Must(GetGroup(1024)), SessionKey(Interleave)))
+
+	xA := new(big.Int).SetBytes(rfc5054.XA)
+	xS := new(big.Int).SetBytes(rfc5054.PremasterSecret)
+	xK := s.computeK(xS)
+
+	assert.Equal(t, rfc2945.InterleavedK, xK)
+
+	m1 := s.computeM1(xA, new(big.Int).SetBytes(rfc5054.XB), xS, xK, rfc5054.Identity, rfc5054.Salt)
+
+	assert.Equal(t, rfc2945.InterleavedM1, m1)
+	assert.Equal(t, rfc2945.InterleavedM2, s.computeM2(xA, xS, m1, xK))
 }
@@ -48,6 +48,13 @@ func TestNewISV(t *testing.T) {
 	assert.Len(t, i.Identity, len(rfc5054.Identity))
 	assert.Len(t, i.Salt, s.Group().Size)
 	assert.Len(t, i.Verifier, s.Group().Size)
+
+	require.NoError(t, s.SetSaltSize(16))
+
+	i = util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))
+
+	assert.Len(t, i.Salt, 16)
+	assert.Error(t, s.SetSaltSize(0))
 }
 
 func TestNewClient(t *testing.T) {
@@ -81,6 +88,25 @@ func TestHandshake(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	testHandshake(t, s)
+}
+
+func TestInterleavedHandshake(t *testing.T) {
+	t.Parallel()
+
+	s, err := srp.NewSRP(crypto.SHA1, util.Must(srp.GetGroup(1024)), srp.SessionKey(srp.Interleave))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := testHandshake(t, s)
+
+	assert.Len(t, key, 2*crypto.SHA1.Size())
+}
+
+func testHandshake(t *testing.T, s *srp.SRP) []byte {
+	t.Helper()
+
 	client, err := s.NewClient(rfc5054.Identity, rfc5054.Password)
 	if err != nil {
 		t.Fatal(err)
@@ -110,5 +136,9 @@ func TestHandshake(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	assert.Equal(t, util.Must(client.S()), server.S())
+
 	assert.Equal(t, client.Key(), server.Key())
+
+	return client.Key()
 }
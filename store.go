@@ -0,0 +1,164 @@
+package srp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// VerifierStore is implemented by persistent storage for ISVs, keyed by their
+// identity. Implementations must be safe for concurrent use.
+type VerifierStore interface {
+	// Get returns the ISV for the identity, or ErrNotFound.
+	Get(ctx context.Context, identity []byte) (*ISV, error)
+
+	// Put stores the ISV, replacing any existing ISV for the same
+	// identity.
+	Put(ctx context.Context, i *ISV) error
+
+	// Delete removes the ISV for the identity, or returns ErrNotFound.
+	Delete(ctx context.Context, identity []byte) error
+
+	// List returns the identities of all stored ISVs.
+	List(ctx context.Context) ([][]byte, error)
+}
+
+// VerifierSwapper is optionally implemented by a VerifierStore that can
+// replace an ISV only if it has not changed since it was read.
+type VerifierSwapper interface {
+	// Swap replaces the stored ISV old with i, or returns ErrISVChanged if
+	// the ISV stored for the identity is no longer old.
+	Swap(ctx context.Context, old, i *ISV) error
+}
+
+var (
+	// ErrNotFound means there is no ISV stored for the identity.
+	ErrNotFound = errors.New("identity not found")
+
+	// ErrISVChanged means the stored ISV changed before it could be
+	// replaced.
+	ErrISVChanged = errors.New("ISV changed")
+)
+
+// NewServerFromStore creates a new Server using the ISV for the identity
+// looked up in store and the client public value.
+func (s *SRP) NewServerFromStore(ctx context.Context, store VerifierStore, identity, xA []byte) (*Server, error) {
+	i, err := store.Get(ctx, identity)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+
+	return s.NewServer(i, xA)
+}
+
+// MemoryStore is a VerifierStore that holds ISVs in memory.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	isvs map[string][]byte
+}
+
+// NewMemoryStore returns a new, empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		isvs: make(map[string][]byte),
+	}
+}
+
+// Get returns the ISV for the identity, or ErrNotFound.
+func (m *MemoryStore) Get(_ context.Context, identity []byte) (*ISV, error) {
+	m.mu.RLock()
+	b, ok := m.isvs[string(identity)]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	i := new(ISV)
+	if err := i.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// Put stores the ISV, replacing any existing ISV for the same identity.
+func (m *MemoryStore) Put(_ context.Context, i *ISV) error {
+	b, err := i.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.isvs[string(i.Identity)] = b
+
+	return nil
+}
+
+// Swap replaces the stored ISV old with i, or returns ErrISVChanged if the ISV
+// stored for the identity is no longer old.
+func (m *MemoryStore) Swap(_ context.Context, old, i *ISV) error {
+	ob, err := old.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	b, err := i.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !bytes.Equal(old.Identity, i.Identity) || !bytes.Equal(m.isvs[string(old.Identity)], ob) {
+		return ErrISVChanged
+	}
+
+	m.isvs[string(i.Identity)] = b
+
+	return nil
+}
+
+// Delete removes the ISV for the identity, or returns ErrNotFound.
+func (m *MemoryStore) Delete(_ context.Context, identity []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.isvs[string(identity)]; !ok {
+		return ErrNotFound
+	}
+
+	delete(m.isvs, string(identity))
+
+	return nil
+}
+
+// List returns the identities of all stored ISVs in lexical order.
+func (m *MemoryStore) List(_ context.Context) ([][]byte, error) {
+	m.mu.RLock()
+	identities := make([]string, 0, len(m.isvs))
+
+	for identity := range m.isvs {
+		identities = append(identities, identity)
+	}
+	m.mu.RUnlock()
+
+	return sortIdentities(identities), nil
+}
+
+func sortIdentities(identities []string) [][]byte {
+	sort.Strings(identities)
+
+	b := make([][]byte, 0, len(identities))
+	for _, identity := range identities {
+		b = append(b, []byte(identity))
+	}
+
+	return b
+}
@@ -0,0 +1,120 @@
+package srp_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testVerifierStore(t *testing.T, store srp.VerifierStore) {
+	t.Helper()
+
+	ctx := context.Background()
+	s := newSRP()
+
+	_, err := store.Get(ctx, rfc5054.Identity)
+	require.ErrorIs(t, err, srp.ErrNotFound)
+	require.ErrorIs(t, store.Delete(ctx, rfc5054.Identity), srp.ErrNotFound)
+
+	i := util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))
+	require.NoError(t, store.Put(ctx, i))
+	assert.Equal(t, i, util.Must(store.Get(ctx, rfc5054.Identity)))
+
+	i = util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))
+	require.NoError(t, store.Put(ctx, i))
+	assert.Equal(t, i, util.Must(store.Get(ctx, rfc5054.Identity)))
+
+	if swapper, ok := store.(srp.VerifierSwapper); ok {
+		j := util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))
+		require.ErrorIs(t, swapper.Swap(ctx, j, j), srp.ErrISVChanged)
+		require.NoError(t, swapper.Swap(ctx, i, j))
+		assert.Equal(t, j, util.Must(store.Get(ctx, rfc5054.Identity)))
+		require.ErrorIs(t, swapper.Swap(ctx, i, j), srp.ErrISVChanged)
+	}
+
+	var wg sync.WaitGroup
+
+	for n := 0; n < 10; n++ {
+		wg.Add(1)
+
+		go func(n int) {
+			defer wg.Done()
+
+			assert.NoError(t, store.Put(ctx, &srp.ISV{
+				Identity: []byte(fmt.Sprintf("user%d", n)),
+				Salt:     []byte{0x01},
+				Verifier: []byte{0x02},
+			}))
+		}(n)
+	}
+
+	wg.Wait()
+
+	identities := util.Must(store.List(ctx))
+	assert.Len(t, identities, 11)
+	assert.Equal(t, rfc5054.Identity, identities[0])
+
+	require.NoError(t, store.Delete(ctx, rfc5054.Identity))
+
+	_, err = store.Get(ctx, rfc5054.Identity)
+	require.ErrorIs(t, err, srp.ErrNotFound)
+	assert.Len(t, util.Must(store.List(ctx)), 10)
+}
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	testVerifierStore(t, srp.NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "isv")
+	store := util.Must(srp.NewFileStore(dir))
+
+	testVerifierStore(t, store)
+
+	// Unrelated files are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), nil, 0o600))
+	assert.Len(t, util.Must(store.List(context.Background())), 10)
+
+	// Identities too long for a file name are stored
+	identity := bytes.Repeat([]byte("a"), 1024)
+	isv := util.Must(newSRP().NewISV(identity, rfc5054.Password))
+	require.NoError(t, store.Put(context.Background(), isv))
+	assert.Equal(t, isv, util.Must(store.Get(context.Background(), identity)))
+	assert.Contains(t, util.Must(store.List(context.Background())), identity)
+}
+
+func TestNewServerFromStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newSRP()
+	store := srp.NewMemoryStore()
+
+	_, err := s.NewServerFromStore(ctx, store, rfc5054.Identity, rfc5054.XA)
+	require.ErrorIs(t, err, srp.ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password))))
+
+	client := util.Must(s.NewClient(rfc5054.Identity, rfc5054.Password))
+	server := util.Must(s.NewServerFromStore(ctx, store, rfc5054.Identity, client.A()))
+
+	m1, err := client.Compute(server.Salt(), server.B())
+	require.NoError(t, err)
+
+	_, err = server.Check(m1)
+	assert.NoError(t, err)
+}
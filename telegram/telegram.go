@@ -0,0 +1,246 @@
+// Package telegram implements the SRP variant used by Telegram to check the
+// two-factor authentication password with account.checkPassword, as
+// documented at https://core.telegram.org/api/srp.
+//
+// The values sent with account.checkPassword are computed from the
+// parameters returned by account.getPassword, checking the server-supplied
+// group is safe before use.
+package telegram
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/util"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	size       = 256
+	bits       = 2048
+	iterations = 100000
+	keyLen     = 64
+	safety     = bits - 64
+	rounds     = 64
+)
+
+// knownPrime is the prime currently sent by Telegram, which avoids repeating
+// the primality tests.
+const knownPrime = "" +
+	"C71CAEB9C6B1C9048E6C522F70F13F73980D40238E3E21C14934D037563D930F" +
+	"48198A0AA7C14058229493D22530F4DBFA336F6E0AC925139543AED44CCE7C37" +
+	"20FD51F69458705AC68CD4FE6B6B13ABDC9746512969328454F18FAF8C595F64" +
+	"2477FE96BB2A941D5BCD1D4AC8CC49880708FA9B378E3C4F3A9060BEE67CF9A4" +
+	"A4A695811051907E162753B56B0F6B410DBA74D8A84B2A14B3144E0EF1284754" +
+	"FD17ED950D5965B4B9DD46582DB1178D169C6BC465B0D6FF9CA3928FEF5B9AE4" +
+	"E418FC15E83EBEA0F87FA9FF5EED70050DED2849F47BF959D956850CE929851F" +
+	"0D8115F635B105EE2E4E15D04B2454BF6F4FADF034B10403119CD8E3B92FCC5B"
+
+var (
+	// ErrUnsafePrime means p is not a 2048-bit safe prime.
+	ErrUnsafePrime = errors.New("p is not a 2048-bit safe prime")
+
+	// ErrUnsafeGenerator means g does not generate a subgroup of prime
+	// order (p-1)/2.
+	ErrUnsafeGenerator = errors.New("g is not a generator of the prime order subgroup")
+
+	// ErrUnsafePublicKey means the server public value is outside of the
+	// recommended range.
+	ErrUnsafePublicKey = errors.New("server public value is outside of the safe range")
+
+	// ErrUnsafeClientPublicKey means the client public value is outside of
+	// the recommended range.
+	ErrUnsafeClientPublicKey = errors.New("client public value is outside of the safe range")
+)
+
+// Algo holds the parameters of the
+// passwordKdfAlgoSHA256SHA256PBKDF2HMACSHA512iter100000SHA256ModPow
+// algorithm returned by account.getPassword.
+type Algo struct {
+	Salt1 []byte
+	Salt2 []byte
+	G     int
+	P     []byte
+}
+
+// InputCheckPasswordSRP holds the values to send with account.checkPassword.
+type InputCheckPasswordSRP struct {
+	SRPID int64
+	A     []byte
+	M1    []byte
+}
+
+// CheckGroup checks p is a 2048-bit safe prime and that g generates the
+// subgroup of prime order (p-1)/2, as Telegram requires clients to do.
+//
+//nolint:cyclop
+func CheckGroup(g int, p []byte) error {
+	n := new(big.Int).SetBytes(p)
+	if n.BitLen() != bits {
+		return ErrUnsafePrime
+	}
+
+	// Use quadratic reciprocity to check g is a quadratic residue mod p
+	mod := func(m int64) int64 {
+		return new(big.Int).Mod(n, big.NewInt(m)).Int64()
+	}
+
+	switch g {
+	case 2: //nolint:gomnd
+		if mod(8) != 7 { //nolint:gomnd
+			return ErrUnsafeGenerator
+		}
+	case 3: //nolint:gomnd
+		if mod(3) != 2 { //nolint:gomnd
+			return ErrUnsafeGenerator
+		}
+	case 4: //nolint:gomnd
+	case 5: //nolint:gomnd
+		if r := mod(5); r != 1 && r != 4 { //nolint:gomnd
+			return ErrUnsafeGenerator
+		}
+	case 6: //nolint:gomnd
+		if r := mod(24); r != 19 && r != 23 { //nolint:gomnd
+			return ErrUnsafeGenerator
+		}
+	case 7: //nolint:gomnd
+		if r := mod(7); r != 3 && r != 5 && r != 6 { //nolint:gomnd
+			return ErrUnsafeGenerator
+		}
+	default:
+		return ErrUnsafeGenerator
+	}
+
+	if strings.EqualFold(n.Text(16), knownPrime) {
+		return nil
+	}
+
+	if !n.ProbablyPrime(rounds) {
+		return ErrUnsafePrime
+	}
+
+	if q := new(big.Int).Rsh(n, 1); !q.ProbablyPrime(rounds) {
+		return ErrUnsafePrime
+	}
+
+	return nil
+}
+
+// NewSRP returns a new srp.SRP struct for the algorithm parameters along with
+// any additional options, after checking the group with CheckGroup. The
+// identity is not used and the password is hashed with the two salts so the
+// salt passed to srp.Client.Compute is ignored.
+func NewSRP(algo *Algo, options ...func(*srp.SRP) error) (*srp.SRP, error) {
+	if err := CheckGroup(algo.G, algo.P); err != nil {
+		return nil, err
+	}
+
+	group := &srp.Group{
+		G:    big.NewInt(int64(algo.G)),
+		N:    new(big.Int).SetBytes(algo.P),
+		Size: size,
+	}
+
+	salt1, salt2 := algo.Salt1, algo.Salt2
+
+	//nolint:wrapcheck
+	return srp.NewSRP(crypto.SHA256, group, append([]func(*srp.SRP) error{
+		srp.X(func(_ *srp.SRP, _, password, _ []byte) *big.Int {
+			return new(big.Int).SetBytes(ComputePH2(password, salt1, salt2))
+		}),
+		srp.SessionKey(func(s *srp.SRP, xS *big.Int) []byte {
+			// k_a = H(s_a)
+			return s.HashBytes(util.Pad(xS, size))
+		}),
+		srp.M1(func(s *srp.SRP, xA, xB, _ *big.Int, xK, _, _ []byte) []byte {
+			// M1 = H(H(p) xor H(g) | H(salt1) | H(salt2) | g_a | g_b | k_a)
+			hp, hg := s.HashBytes(s.Group().N.Bytes()), s.HashBytes(util.Pad(s.Group().G, size))
+			for i := range hp {
+				hp[i] ^= hg[i]
+			}
+
+			return s.HashBytes(hp, s.HashBytes(salt1), s.HashBytes(salt2), util.Pad(xA, size), util.Pad(xB, size), xK)
+		}),
+	}, options...)...)
+}
+
+// NewInputCheckPasswordSRP computes the values to send with
+// account.checkPassword from the algorithm parameters, srp_id and srp_B
+// returned by account.getPassword. Any options are passed to NewSRP.
+func NewInputCheckPasswordSRP(algo *Algo, srpID int64, srpB, password []byte, options ...func(*srp.SRP) error) (*InputCheckPasswordSRP, error) {
+	s, err := NewSRP(algo, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !safePublicKey(s, srpB) {
+		return nil, ErrUnsafePublicKey
+	}
+
+	client, err := s.NewClient(nil, password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	if !safePublicKey(s, client.A()) {
+		return nil, ErrUnsafeClientPublicKey
+	}
+
+	m1, err := client.Compute(nil, srpB)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute proof: %w", err)
+	}
+
+	return &InputCheckPasswordSRP{
+		SRPID: srpID,
+		A:     util.Pad(new(big.Int).SetBytes(client.A()), size),
+		M1:    m1,
+	}, nil
+}
+
+// safePublicKey checks 2^{2048-64} < x < p - 2^{2048-64}, which also ensures
+// 1 < x < p-1.
+func safePublicKey(s *srp.SRP, b []byte) bool {
+	x, min := new(big.Int).SetBytes(b), new(big.Int).Lsh(big.NewInt(1), safety)
+
+	return x.Cmp(min) > 0 && x.Cmp(new(big.Int).Sub(s.Group().N, min)) < 0
+}
+
+// NewPasswordHash computes the verifier to send as new_password_hash with
+// account.updatePasswordSettings when setting a new password. The algorithm
+// parameters should include the new random salt. Any options are passed to
+// NewSRP.
+func NewPasswordHash(algo *Algo, password []byte, options ...func(*srp.SRP) error) ([]byte, error) {
+	s, err := NewSRP(algo, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	// v = g^x mod p
+	x := new(big.Int).SetBytes(ComputePH2(password, algo.Salt1, algo.Salt2))
+
+	return util.Pad(new(big.Int).Exp(s.Group().G, x, s.Group().N), size), nil
+}
+
+// ComputePH2 computes the secondary password hashing function, which is used
+// as x.
+func ComputePH2(password, salt1, salt2 []byte) []byte {
+	// PH2 = SH(pbkdf2(sha512, PH1(password, salt1, salt2), salt1, 100000), salt2)
+	return sh(pbkdf2.Key(sh(sh(password, salt1), salt2), salt1, iterations, keyLen, sha512.New), salt2)
+}
+
+func sh(data, salt []byte) []byte {
+	// SH(data, salt) = H(salt | data | salt)
+	h := sha256.New()
+	_, _ = h.Write(salt)
+	_, _ = h.Write(data)
+	_, _ = h.Write(salt)
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,176 @@
+package telegram_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/rfc5054"
+	vectors "github.com/bodgit/srp/internal/telegram"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/bodgit/srp/telegram"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAlgo() *telegram.Algo {
+	return &telegram.Algo{
+		Salt1: vectors.Salt1,
+		Salt2: vectors.Salt2,
+		G:     vectors.G,
+		P:     vectors.P,
+	}
+}
+
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	r := bytes.NewReader(vectors.PrivateA)
+
+	input, err := telegram.NewInputCheckPasswordSRP(newAlgo(), 1234, vectors.SRPB, vectors.Password, srp.Rand(r))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1234), input.SRPID)
+	assert.Equal(t, vectors.XA, input.A)
+	assert.Equal(t, vectors.M1, input.M1)
+
+	// a = 1 gives g_a = g which is rejected
+	r = bytes.NewReader(util.Pad(big.NewInt(1), 256))
+
+	_, err = telegram.NewInputCheckPasswordSRP(newAlgo(), 1234, vectors.SRPB, vectors.Password, srp.Rand(r))
+	assert.ErrorIs(t, err, telegram.ErrUnsafeClientPublicKey)
+}
+
+func TestCheckGroup(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		name string
+		g    int
+		p    []byte
+		err  error
+	}{
+		{
+			"telegram",
+			vectors.G,
+			vectors.P,
+			nil,
+		},
+		{
+			"telegram with g = 7",
+			7,
+			vectors.P,
+			nil,
+		},
+		{
+			"telegram with g = 2",
+			2,
+			vectors.P,
+			telegram.ErrUnsafeGenerator,
+		},
+		{
+			"telegram with g = 5",
+			5,
+			vectors.P,
+			telegram.ErrUnsafeGenerator,
+		},
+		{
+			"telegram with g = 8",
+			8,
+			vectors.P,
+			telegram.ErrUnsafeGenerator,
+		},
+		{
+			"rfc5054",
+			5,
+			util.Must(util.BytesFromHexString(rfc5054.Hex2048)),
+			nil,
+		},
+		{
+			"not prime",
+			4,
+			new(big.Int).Add(new(big.Int).SetBytes(vectors.P), big.NewInt(2)).Bytes(),
+			telegram.ErrUnsafePrime,
+		},
+		{
+			"too small",
+			4,
+			util.Must(util.BytesFromHexString(rfc5054.Hex1536)),
+			telegram.ErrUnsafePrime,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, telegram.CheckGroup(table.g, table.p), table.err)
+		})
+	}
+}
+
+func TestNewInputCheckPasswordSRP(t *testing.T) {
+	t.Parallel()
+
+	p := new(big.Int).SetBytes(vectors.P)
+	safety := new(big.Int).Lsh(big.NewInt(1), 2048-64)
+
+	tables := []struct {
+		name string
+		b    []byte
+		err  error
+	}{
+		{
+			"valid",
+			vectors.SRPB,
+			nil,
+		},
+		{
+			"too small",
+			safety.Bytes(),
+			telegram.ErrUnsafePublicKey,
+		},
+		{
+			"too large",
+			new(big.Int).Sub(p, safety).Bytes(),
+			telegram.ErrUnsafePublicKey,
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := telegram.NewInputCheckPasswordSRP(newAlgo(), 0, table.b, vectors.Password)
+			assert.ErrorIs(t, err, table.err)
+		})
+	}
+}
+
+func TestNewPasswordHash(t *testing.T) {
+	t.Parallel()
+
+	algo := newAlgo()
+
+	v, err := telegram.NewPasswordHash(algo, vectors.Password)
+	require.NoError(t, err)
+	assert.Len(t, v, 256)
+
+	s, err := telegram.NewSRP(algo)
+	require.NoError(t, err)
+
+	// The server never sees A before sending B, so use a deferred server
+	server, err := s.NewDeferredServer(&srp.ISV{Verifier: v})
+	require.NoError(t, err)
+
+	input, err := telegram.NewInputCheckPasswordSRP(algo, 0, server.B(), vectors.Password)
+	require.NoError(t, err)
+
+	_, err = server.Check(input.A, input.M1)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,91 @@
+// Package thinbus implements the SRP-6a profile used by the Thinbus
+// JavaScript library and its Java server routines, so that a Server from this
+// module can authenticate Thinbus browser sessions.
+//
+// Thinbus hashes the lowercase hex strings of numbers without leading zeros
+// rather than their bytes, apart from the multiplier which is computed the
+// same way as RFC 5054. The salt is a hex string which keeps any leading
+// zeros, it should be decoded with encoding/hex before storing it in an ISV.
+// Thinbus defaults to the RFC 5054 2048-bit group and SHA-256.
+//
+// This package is experimental. The test vectors are not taken from the
+// Thinbus test suite or a real Thinbus session so it has not been verified
+// against a real Thinbus client, and it may change once it has been.
+package thinbus
+
+import (
+	"crypto"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/util"
+)
+
+// ErrInvalidHex means a string sent by Thinbus is not a hex number.
+var ErrInvalidHex = errors.New("invalid hex number")
+
+// NewSRP returns a new srp.SRP struct using the chosen hash and group along
+// with any additional options.
+func NewSRP(hash crypto.Hash, group *srp.Group, options ...func(*srp.SRP) error) (*srp.SRP, error) {
+	//nolint:wrapcheck
+	return srp.NewSRP(hash, group, append([]func(*srp.SRP) error{
+		srp.X(ComputeX),
+		srp.U(ComputeU),
+		srp.SessionKey(ComputeSessionKey),
+		srp.M1(ComputeM1),
+		srp.M2(ComputeM2),
+	}, options...)...)
+}
+
+// ComputeX calculates the X value according to Thinbus.
+func ComputeX(s *srp.SRP, identity, password, salt []byte) *big.Int {
+	// x = H(upper(s | H(I | ":" | P)))
+	h := EncodeHex(s.HashBytes(identity, []byte(":"), password))
+
+	return s.HashInt([]byte(strings.ToUpper(hex.EncodeToString(salt) + h)))
+}
+
+// ComputeU calculates the U value according to Thinbus.
+func ComputeU(s *srp.SRP, xA, xB *big.Int) *big.Int {
+	// u = H(A | B)
+	return s.HashInt([]byte(xA.Text(16) + xB.Text(16)))
+}
+
+// ComputeSessionKey calculates the session key according to Thinbus, which
+// matches the key returned by its getSessionKey function.
+func ComputeSessionKey(s *srp.SRP, xS *big.Int) []byte {
+	// K = H(S)
+	return s.HashBytes([]byte(xS.Text(16)))
+}
+
+// ComputeM1 calculates the M1 proof according to Thinbus.
+func ComputeM1(s *srp.SRP, xA, xB, xS *big.Int, _, _, _ []byte) []byte {
+	// M1 = H(A | B | S)
+	return s.HashBytes([]byte(xA.Text(16) + xB.Text(16) + xS.Text(16)))
+}
+
+// ComputeM2 calculates the M2 proof according to Thinbus.
+func ComputeM2(s *srp.SRP, xA, xS *big.Int, m1, _ []byte) []byte {
+	// M2 = H(A | M1 | S)
+	return s.HashBytes([]byte(xA.Text(16) + EncodeHex(m1) + xS.Text(16)))
+}
+
+// EncodeHex returns b as the lowercase hex string without leading zeros that
+// Thinbus uses for numbers and proofs.
+func EncodeHex(b []byte) string {
+	return new(big.Int).SetBytes(b).Text(16)
+}
+
+// DecodeHex decodes a hex number sent by Thinbus, padding it to n bytes. It
+// should not be used for salts.
+func DecodeHex(s string, n int) ([]byte, error) {
+	x, ok := new(big.Int).SetString(s, 16)
+	if !ok || x.Sign() < 0 {
+		return nil, ErrInvalidHex
+	}
+
+	return util.Pad(x, n), nil
+}
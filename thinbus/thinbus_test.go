@@ -0,0 +1,138 @@
+package thinbus_test
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/bodgit/srp"
+	vectors "github.com/bodgit/srp/internal/thinbus"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/bodgit/srp/thinbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, s string, n int) []byte {
+	t.Helper()
+
+	b, err := thinbus.DecodeHex(s, n)
+	require.NoError(t, err)
+
+	return b
+}
+
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	salt := util.Must(hex.DecodeString(vectors.Salt))
+
+	s, err := thinbus.NewSRP(crypto.SHA256, util.Must(srp.GetGroup(2048)), srp.Rand(bytes.NewReader(salt)),
+		srp.SaltSize(len(salt)))
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.X, thinbus.ComputeX(s, []byte(vectors.Identity), []byte(vectors.Password), salt).Text(16))
+
+	i, err := s.NewISV([]byte(vectors.Identity), []byte(vectors.Password))
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.V, thinbus.EncodeHex(i.Verifier))
+
+	require.NoError(t, s.SetRand(bytes.NewReader(decode(t, vectors.PrivateA, 256))))
+
+	client, err := s.NewClient([]byte(vectors.Identity), []byte(vectors.Password))
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.XA, thinbus.EncodeHex(client.A()))
+
+	require.NoError(t, s.SetRand(bytes.NewReader(decode(t, vectors.PrivateB, 256))))
+
+	server, err := s.NewServer(i, decode(t, vectors.XA, 0))
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.XB, thinbus.EncodeHex(server.B()))
+
+	m1, err := client.Compute(server.Salt(), decode(t, vectors.XB, 0))
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.U, thinbus.EncodeHex(util.Must(client.U())))
+	assert.Equal(t, vectors.PremasterSecret, thinbus.EncodeHex(util.Must(client.S())))
+	assert.Equal(t, vectors.SessionKey, hex.EncodeToString(client.Key()))
+	assert.Equal(t, vectors.M1, thinbus.EncodeHex(m1))
+
+	// M1 is sent without leading zeros so must be padded to the hash size
+	m2, err := server.Check(decode(t, vectors.M1, crypto.SHA256.Size()))
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.M2, thinbus.EncodeHex(m2))
+	assert.NoError(t, client.Check(m2))
+}
+
+func TestMultiplier(t *testing.T) {
+	t.Parallel()
+
+	g := util.Must(srp.GetGroup(2048))
+	s := util.Must(thinbus.NewSRP(crypto.SHA256, g, srp.Rand(bytes.NewReader(decode(t, vectors.PrivateB, 256)))))
+
+	i := &srp.ISV{
+		Salt:     util.Must(hex.DecodeString(vectors.Salt)),
+		Verifier: decode(t, vectors.V, 0),
+	}
+
+	server := util.Must(s.NewServer(i, decode(t, vectors.XA, 0)))
+
+	// k = (B - g^b) * v^-1 mod N
+	b, ok := new(big.Int).SetString(vectors.PrivateB, 16)
+	require.True(t, ok)
+
+	k := new(big.Int).SetBytes(server.B())
+	k.Sub(k, new(big.Int).Exp(g.G, b, g.N))
+	k.Mul(k, new(big.Int).ModInverse(new(big.Int).SetBytes(i.Verifier), g.N))
+	k.Mod(k, g.N)
+
+	assert.Equal(t, vectors.K, k.Text(16))
+}
+
+func TestDecodeHex(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		s    string
+		n    int
+		want []byte
+		err  error
+	}{
+		{
+			"abc",
+			0,
+			[]byte{0x0a, 0xbc},
+			nil,
+		},
+		{
+			"abc",
+			4,
+			[]byte{0x00, 0x00, 0x0a, 0xbc},
+			nil,
+		},
+		{
+			"xyz",
+			0,
+			nil,
+			thinbus.ErrInvalidHex,
+		},
+		{
+			"-1",
+			0,
+			nil,
+			thinbus.ErrInvalidHex,
+		},
+	}
+
+	for _, table := range tables {
+		b, err := thinbus.DecodeHex(table.s, table.n)
+		assert.Equal(t, table.want, b)
+		assert.ErrorIs(t, err, table.err)
+	}
+}
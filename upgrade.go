@@ -0,0 +1,172 @@
+package srp
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const upgradeKeySize = 32
+
+var (
+	// ErrInvalidUpgrade means a sealed ISV could not be opened or is not
+	// acceptable as an upgrade.
+	ErrInvalidUpgrade = errors.New("invalid upgrade")
+
+	errUpgradeNotRequired = errors.New("upgrade not required")
+)
+
+// SealISV marshals the ISV and encrypts it with AES-GCM using a key derived
+// from the session key shared between the client and server. This allows the
+// client to send a new ISV to the server after authenticating.
+func SealISV(key []byte, i *ISV, rand io.Reader) ([]byte, error) {
+	aead, err := upgradeAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := i.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randBytes(rand, aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, b, nil), nil
+}
+
+// OpenISV decrypts and unmarshals an ISV sealed with SealISV using the same
+// session key.
+func OpenISV(key, sealed []byte) (*ISV, error) {
+	aead, err := upgradeAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrInvalidUpgrade
+	}
+
+	b, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidUpgrade, err.Error())
+	}
+
+	i := new(ISV)
+	if err := i.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// NewUpgrade creates a new ISV for the client identity and password using
+// target and seals it with the session key so it can be sent to the server.
+// It fails unless the server proof has been successfully checked with
+// c.Check().
+func (c *Client) NewUpgrade(target *SRP) ([]byte, error) {
+	if !c.verified {
+		return nil, errClientNotVerified
+	}
+
+	i, err := target.NewISV(c.identity, c.password)
+	if err != nil {
+		return nil, err
+	}
+
+	return SealISV(c.Key(), i, c.s.rand)
+}
+
+// Upgrade opens the ISV sealed by the client with Client.NewUpgrade using
+// the session key from result, verifies it matches the authenticated
+// identity and the parameters of the Authenticator, and then replaces the
+// stored ISV. ErrISVChanged is returned if the stored ISV is no longer the
+// one the client authenticated with. This is atomic if the VerifierStore
+// implements VerifierSwapper, otherwise a concurrent change to the stored ISV
+// may be overwritten.
+func (a *Authenticator) Upgrade(ctx context.Context, result *Result, sealed []byte) error {
+	if result.Upgrade == nil {
+		return errUpgradeNotRequired
+	}
+
+	if result.isv == nil {
+		return ErrInvalidUpgrade
+	}
+
+	i, err := OpenISV(result.Key, sealed)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(i.Identity, result.Identity) || !i.Params.Equal(a.params) {
+		return ErrInvalidUpgrade
+	}
+
+	if v := a.srp.Decode(i.Verifier); v.Sign() == 0 || v.Cmp(a.params.Group.N) >= 0 {
+		return ErrInvalidUpgrade
+	}
+
+	if swapper, ok := a.verifiers.(VerifierSwapper); ok {
+		//nolint:wrapcheck
+		return swapper.Swap(ctx, result.isv, i)
+	}
+
+	current, err := a.verifiers.Get(ctx, i.Identity)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrISVChanged
+		}
+
+		return err //nolint:wrapcheck
+	}
+
+	if !equalISV(current, result.isv) {
+		return ErrISVChanged
+	}
+
+	//nolint:wrapcheck
+	return a.verifiers.Put(ctx, i)
+}
+
+func equalISV(a, b *ISV) bool {
+	ab, err := a.MarshalBinary()
+	if err != nil {
+		return false
+	}
+
+	bb, err := b.MarshalBinary()
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(ab, bb)
+}
+
+func upgradeAEAD(key []byte) (cipher.AEAD, error) { //nolint:ireturn
+	k := make([]byte, upgradeKeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, nil, []byte("srp verifier upgrade")), k); err != nil {
+		return nil, fmt.Errorf("unable to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AEAD: %w", err)
+	}
+
+	return aead, nil
+}
@@ -0,0 +1,258 @@
+package srp_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/rfc5054"
+	"github.com/bodgit/srp/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTarget() *srp.SRP {
+	return util.Must(srp.NewSRP(crypto.SHA256, util.Must(srp.GetGroup(2048)),
+		srp.UseKDF(&srp.PBKDF2{Iterations: 1000, KeyLen: 32})))
+}
+
+// login authenticates as a client that only knows the target parameters to
+// begin with, starting again with the parameters from the challenge if the
+// stored ISV uses different parameters.
+func login(t *testing.T, a *srp.Authenticator) (*srp.Client, *srp.Result) {
+	t.Helper()
+
+	ctx := context.Background()
+	client := util.Must(newTarget().NewClient(rfc5054.Identity, rfc5054.Password))
+
+	challenge, err := a.Start(ctx, rfc5054.Identity, client.A())
+	require.NoError(t, err)
+	require.NotNil(t, challenge.Params)
+
+	if !challenge.Params.Equal(util.Must(newTarget().Params())) {
+		s, err := srp.NewSRPFromParams(challenge.Params)
+		require.NoError(t, err)
+
+		client = util.Must(s.NewClient(rfc5054.Identity, rfc5054.Password))
+
+		challenge, err = a.Start(ctx, rfc5054.Identity, client.A())
+		require.NoError(t, err)
+	}
+
+	m1, err := client.Compute(challenge.Salt, challenge.B)
+	require.NoError(t, err)
+
+	result, err := a.Finish(ctx, challenge.ID, m1)
+	require.NoError(t, err)
+	require.NoError(t, client.Check(result.M2))
+
+	return client, result
+}
+
+func TestAuthenticator_Upgrade(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		name    string
+		isv     func(*srp.ISV) *srp.ISV
+		options []func(*srp.Authenticator) error
+	}{
+		{
+			"versioned",
+			func(i *srp.ISV) *srp.ISV {
+				return i
+			},
+			nil,
+		},
+		{
+			"legacy",
+			func(i *srp.ISV) *srp.ISV {
+				return &srp.ISV{
+					Identity: i.Identity,
+					Salt:     i.Salt,
+					Verifier: i.Verifier,
+				}
+			},
+			[]func(*srp.Authenticator) error{
+				srp.LegacyParams(util.Must(newSRP().Params())),
+			},
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			old, target := newSRP(), newTarget()
+
+			store := srp.NewMemoryStore()
+			require.NoError(t, store.Put(ctx, table.isv(util.Must(old.NewISV(rfc5054.Identity, rfc5054.Password)))))
+
+			a, err := srp.NewAuthenticator(target, store, table.options...)
+			require.NoError(t, err)
+
+			defer a.Close()
+
+			client, result := login(t, a)
+			require.NotNil(t, result.Upgrade)
+
+			sealed, err := client.NewUpgrade(util.Must(srp.NewSRPFromParams(result.Upgrade)))
+			require.NoError(t, err)
+
+			require.NoError(t, a.Upgrade(ctx, result, sealed))
+			assert.Equal(t, util.Must(target.Params()), util.Must(store.Get(ctx, rfc5054.Identity)).Params)
+
+			_, result = login(t, a)
+			assert.Nil(t, result.Upgrade)
+			assert.Error(t, a.Upgrade(ctx, result, sealed))
+		})
+	}
+}
+
+func TestAuthenticator_UpgradeInvalid(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	old, target := newSRP(), newTarget()
+
+	store := srp.NewMemoryStore()
+	require.NoError(t, store.Put(ctx, util.Must(old.NewISV(rfc5054.Identity, rfc5054.Password))))
+
+	a, err := srp.NewAuthenticator(target, store)
+	require.NoError(t, err)
+
+	defer a.Close()
+
+	_, result := login(t, a)
+	require.NotNil(t, result.Upgrade)
+
+	tables := []*srp.ISV{
+		// Wrong identity
+		util.Must(target.NewISV([]byte("bob"), rfc5054.Password)),
+		// Wrong parameters
+		util.Must(old.NewISV(rfc5054.Identity, rfc5054.Password)),
+		// Invalid verifier
+		{
+			Identity: rfc5054.Identity,
+			Salt:     []byte{0x01},
+			Verifier: []byte{0x00},
+			Params:   util.Must(target.Params()),
+		},
+	}
+
+	for _, table := range tables {
+		sealed := util.Must(srp.SealISV(result.Key, table, rand.Reader))
+		assert.ErrorIs(t, a.Upgrade(ctx, result, sealed), srp.ErrInvalidUpgrade)
+	}
+
+	sealed := util.Must(srp.SealISV(result.Key, util.Must(target.NewISV(rfc5054.Identity, rfc5054.Password)), nil))
+	sealed[len(sealed)-1] ^= 0xff
+	assert.ErrorIs(t, a.Upgrade(ctx, result, sealed), srp.ErrInvalidUpgrade)
+	assert.ErrorIs(t, a.Upgrade(ctx, result, sealed[:4]), srp.ErrInvalidUpgrade)
+
+	assert.Equal(t, util.Must(old.Params()), util.Must(store.Get(ctx, rfc5054.Identity)).Params)
+}
+
+// verifierStore hides any optional methods of the VerifierStore.
+type verifierStore struct {
+	srp.VerifierStore
+}
+
+func TestAuthenticator_UpgradeChanged(t *testing.T) {
+	t.Parallel()
+
+	tables := []struct {
+		name  string
+		store func(srp.VerifierStore) srp.VerifierStore
+	}{
+		{
+			"swapper",
+			func(store srp.VerifierStore) srp.VerifierStore {
+				return store
+			},
+		},
+		{
+			"fallback",
+			func(store srp.VerifierStore) srp.VerifierStore {
+				return verifierStore{store}
+			},
+		},
+	}
+
+	for _, table := range tables {
+		table := table
+
+		t.Run(table.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			old, target := newSRP(), newTarget()
+
+			store := srp.NewMemoryStore()
+			require.NoError(t, store.Put(ctx, util.Must(old.NewISV(rfc5054.Identity, rfc5054.Password))))
+
+			a, err := srp.NewAuthenticator(target, table.store(store))
+			require.NoError(t, err)
+
+			defer a.Close()
+
+			client, result := login(t, a)
+			require.NotNil(t, result.Upgrade)
+
+			sealed := util.Must(client.NewUpgrade(target))
+
+			// The password is changed before the upgrade
+			changed := util.Must(old.NewISV(rfc5054.Identity, []byte("changed")))
+			require.NoError(t, store.Put(ctx, changed))
+
+			assert.ErrorIs(t, a.Upgrade(ctx, result, sealed), srp.ErrISVChanged)
+			assert.Equal(t, changed, util.Must(store.Get(ctx, rfc5054.Identity)))
+
+			// The ISV is removed before the upgrade
+			require.NoError(t, store.Delete(ctx, rfc5054.Identity))
+			assert.ErrorIs(t, a.Upgrade(ctx, result, sealed), srp.ErrISVChanged)
+		})
+	}
+}
+
+func TestClient_NewUpgrade(t *testing.T) {
+	t.Parallel()
+
+	s := newSRP()
+	client := util.Must(s.NewClient(rfc5054.Identity, rfc5054.Password))
+
+	_, err := client.NewUpgrade(newTarget())
+	assert.Error(t, err)
+
+	// An empty proof cannot be checked before the server public value is set
+	require.Error(t, client.Check(nil))
+
+	_, err = client.NewUpgrade(newTarget())
+	assert.Error(t, err)
+
+	server := util.Must(s.NewServer(util.Must(s.NewISV(rfc5054.Identity, rfc5054.Password)), client.A()))
+
+	m1, err := client.Compute(server.Salt(), server.B())
+	require.NoError(t, err)
+
+	// The server proof has not been checked
+	_, err = client.NewUpgrade(newTarget())
+	assert.Error(t, err)
+
+	require.Error(t, client.Check(make([]byte, len(m1))))
+
+	_, err = client.NewUpgrade(newTarget())
+	assert.Error(t, err)
+
+	m2, err := server.Check(m1)
+	require.NoError(t, err)
+	require.NoError(t, client.Check(m2))
+
+	_, err = client.NewUpgrade(newTarget())
+	assert.NoError(t, err)
+}
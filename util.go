@@ -9,17 +9,21 @@ import (
 	"math/big"
 )
 
-func randBytes(n int) ([]byte, error) {
+func randBytes(r io.Reader, n int) ([]byte, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+
 	b := make([]byte, n)
-	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+	if _, err := io.ReadFull(r, b); err != nil {
 		return nil, fmt.Errorf("unable to read random bytes: %w", err)
 	}
 
 	return b, nil
 }
 
-func randBigInt(n int) (*big.Int, error) {
-	b, err := randBytes(n)
+func randBigInt(r io.Reader, n int) (*big.Int, error) {
+	b, err := randBytes(r, n)
 	if err != nil {
 		return nil, err
 	}
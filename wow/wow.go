@@ -0,0 +1,96 @@
+// Package wow implements the SRP6 profile used by the classic World of
+// Warcraft logon protocol, as implemented by private servers such as
+// TrinityCore. It uses a 256-bit group with a generator of 7, a fixed
+// multiplier of 3, SHA-1, little-endian numbers and a variant of the
+// SHA_Interleave session key. The identity and password are upper-cased
+// before use.
+//
+// This package is experimental. The test vectors are not taken from a real
+// exchange or the TrinityCore tests so it has not been verified against a real
+// server or client, and it may change once it has been.
+//
+// Warning: The group is far too small to be secure and this profile should
+// only be used to interoperate with existing servers and clients.
+package wow
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha1" //nolint:gosec // The protocol uses SHA-1
+	"math/big"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/util"
+)
+
+const (
+	generator = 7
+	bits      = 256
+	prime     = "894B645E89E1535BBDAD5B8B290650530801B18EBFBF5E8FAB3C82872A3E9BB7"
+)
+
+// NewGroup returns the 256-bit group used by the logon protocol.
+func NewGroup() *srp.Group {
+	return util.Must(srp.NewGroup(generator, bits, prime))
+}
+
+// NewSRP returns a new srp.SRP struct using the profile along with any
+// additional options.
+func NewSRP(options ...func(*srp.SRP) error) (*srp.SRP, error) {
+	//nolint:wrapcheck
+	return srp.NewSRP(crypto.SHA1, NewGroup(), append([]func(*srp.SRP) error{
+		srp.Order(srp.LittleEndian),
+		srp.K(srp.MultiplierSRP6),
+		srp.X(func(s *srp.SRP, identity, password, salt []byte) *big.Int {
+			// x = H(s | H(I | ":" | P))
+			return s.HashInt(salt, s.HashBytes(bytes.ToUpper(identity), []byte(":"), bytes.ToUpper(password)))
+		}),
+		srp.SessionKey(SessionKey),
+		srp.M1(func(s *srp.SRP, xA, xB, _ *big.Int, xK, identity, salt []byte) []byte {
+			// M1 = H(H(N) XOR H(g) | H(I) | s | A | B | K), where g is
+			// not padded
+			hn, hg := s.HashBytes(s.Encode(s.Group().N)), s.HashBytes(s.Group().G.Bytes())
+			for i := range hn {
+				hn[i] ^= hg[i]
+			}
+
+			return s.HashBytes(hn, s.HashBytes(bytes.ToUpper(identity)), salt, s.Encode(xA), s.Encode(xB), xK)
+		}),
+	}, options...)...)
+}
+
+// SessionKey computes the session key K from the premaster secret S using the
+// SHA_Interleave function as implemented by TrinityCore. Unlike RFC 2945 the
+// leading zero bytes are removed from the little-endian encoding of S, which
+// are its least significant bytes, followed by another if an odd number were
+// removed.
+func SessionKey(s *srp.SRP, xS *big.Int) []byte {
+	t := s.Encode(xS)
+
+	n := 0
+	for n < len(t) && t[n] == 0 {
+		n++
+	}
+
+	if n&1 == 1 && n < len(t) {
+		n++
+	}
+
+	t = t[n:]
+
+	e := make([]byte, len(t)/2)
+	f := make([]byte, len(t)/2)
+
+	for i := range e {
+		e[i], f[i] = t[2*i], t[2*i+1]
+	}
+
+	g, h := s.HashBytes(e), s.HashBytes(f)
+
+	k := make([]byte, len(g)+len(h))
+	for i := range g {
+		k[2*i], k[2*i+1] = g[i], h[i]
+	}
+
+	return k
+}
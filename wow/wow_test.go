@@ -0,0 +1,82 @@
+package wow_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bodgit/srp"
+	"github.com/bodgit/srp/internal/util"
+	vectors "github.com/bodgit/srp/internal/wow"
+	"github.com/bodgit/srp/wow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectors(t *testing.T) {
+	t.Parallel()
+
+	s, err := wow.NewSRP(srp.Rand(bytes.NewReader(vectors.Salt)))
+	require.NoError(t, err)
+
+	i, err := s.NewISV(vectors.Identity, vectors.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.Salt, i.Salt)
+	assert.Equal(t, vectors.V, i.Verifier)
+
+	require.NoError(t, s.SetRand(bytes.NewReader(vectors.PrivateA)))
+
+	client, err := s.NewClient(vectors.Identity, vectors.Password)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.XA, client.A())
+
+	require.NoError(t, s.SetRand(bytes.NewReader(vectors.PrivateB)))
+
+	server, err := s.NewServer(i, client.A())
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.XB, server.B())
+
+	m1, err := client.Compute(server.Salt(), server.B())
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.U, util.Must(client.U()))
+	assert.Equal(t, vectors.PremasterSecret, util.Must(client.S()))
+	assert.Equal(t, vectors.K, client.Key())
+	assert.Equal(t, vectors.M1, m1)
+
+	m2, err := server.Check(m1)
+	require.NoError(t, err)
+
+	assert.Equal(t, vectors.M2, m2)
+	assert.NoError(t, client.Check(m2))
+}
+
+func TestSessionKey(t *testing.T) {
+	t.Parallel()
+
+	s := util.Must(wow.NewSRP())
+	xS := s.Decode(vectors.PremasterSecret)
+
+	// The premaster secret has a zero low byte which TrinityCore removes
+	assert.Equal(t, vectors.K, wow.SessionKey(s, xS))
+	assert.NotEqual(t, vectors.K, srp.Interleave(s, xS))
+}
+
+func TestCase(t *testing.T) {
+	t.Parallel()
+
+	s := util.Must(wow.NewSRP())
+	i := util.Must(s.NewISV(vectors.Identity, vectors.Password))
+
+	// The client may send the identity and password in any case
+	client := util.Must(s.NewClient(bytes.ToUpper(vectors.Identity), bytes.ToUpper(vectors.Password)))
+	server := util.Must(s.NewServer(i, client.A()))
+
+	m1, err := client.Compute(server.Salt(), server.B())
+	require.NoError(t, err)
+
+	_, err = server.Check(m1)
+	assert.NoError(t, err)
+}